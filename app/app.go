@@ -9,6 +9,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/sifchain/peggy/x/ethbridge"
+	ethbridgeclient "github.com/sifchain/peggy/x/ethbridge/client"
 	"github.com/sifchain/peggy/x/oracle"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
@@ -20,10 +21,16 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
 	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 )
 
 const (
@@ -45,8 +52,13 @@ var (
 		genutil.AppModuleBasic{},
 		bank.AppModuleBasic{},
 		staking.AppModuleBasic{},
+		slashing.AppModuleBasic{},
 		params.AppModuleBasic{},
 		supply.AppModuleBasic{},
+		crisis.AppModuleBasic{},
+		gov.NewAppModuleBasic(paramsclient.ProposalHandler, upgradeclient.ProposalHandler,
+			ethbridgeclient.ResolveProphecyProposalHandler),
+		upgrade.AppModuleBasic{},
 		oracle.AppModuleBasic{},
 		ethbridge.AppModuleBasic{},
 	)
@@ -56,6 +68,7 @@ var (
 		auth.FeeCollectorName:     nil,
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
+		gov.ModuleName:            {supply.Burner},
 		ethbridge.ModuleName:      {supply.Burner, supply.Minter},
 	}
 )
@@ -80,12 +93,15 @@ type EthereumBridgeApp struct {
 	tkeys map[string]*sdk.TransientStoreKey
 
 	// SDK keepers
-	// TODO: add governance keeper
-	AccountKeeper auth.AccountKeeper
-	BankKeeper    bank.Keeper
-	StakingKeeper staking.Keeper
-	SupplyKeeper  supply.Keeper
-	ParamsKeeper  params.Keeper
+	AccountKeeper  auth.AccountKeeper
+	BankKeeper     bank.Keeper
+	StakingKeeper  staking.Keeper
+	SlashingKeeper slashing.Keeper
+	SupplyKeeper   supply.Keeper
+	ParamsKeeper   params.Keeper
+	GovKeeper      gov.Keeper
+	CrisisKeeper   crisis.Keeper
+	UpgradeKeeper  upgrade.Keeper
 
 	// EthBridge keepers
 	BridgeKeeper ethbridge.Keeper
@@ -93,11 +109,16 @@ type EthereumBridgeApp struct {
 
 	// the module manager
 	mm *module.Manager
+
+	// upgradeChecked is set once the first block processed since the app was loaded checked whether the chain runs
+	// the first release of the bridge, see BeginBlocker
+	upgradeChecked bool
 }
 
-// NewEthereumBridgeApp is a constructor function for EthereumBridgeApp
+// NewEthereumBridgeApp is a constructor function for EthereumBridgeApp. The registered invariants are asserted
+// every invCheckPeriod blocks, never if it is zero. The upgrades planned at skipUpgradeHeights are skipped.
 func NewEthereumBridgeApp(
-	logger log.Logger, db dbm.DB, loadLatest bool,
+	logger log.Logger, db dbm.DB, loadLatest bool, skipUpgradeHeights map[int64]bool, invCheckPeriod uint,
 	baseAppOptions ...func(*bam.BaseApp),
 ) *EthereumBridgeApp {
 	// First define the top level codec that will be shared by the different modules
@@ -107,9 +128,11 @@ func NewEthereumBridgeApp(
 	bApp := bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc))
 	bApp.SetAppVersion(version.Version)
 
+	// the stores added since the first release of the bridge are loaded empty by the multistore, their state is
+	// initialized by the upgrade handler, see registerUpgradeHandlers
 	keys := sdk.NewKVStoreKeys(
-		bam.MainStoreKey, auth.StoreKey, staking.StoreKey,
-		supply.StoreKey, oracle.StoreKey, params.StoreKey,
+		bam.MainStoreKey, auth.StoreKey, staking.StoreKey, slashing.StoreKey,
+		supply.StoreKey, gov.StoreKey, oracle.StoreKey, ethbridge.StoreKey, params.StoreKey, upgrade.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
 
@@ -127,17 +150,50 @@ func NewEthereumBridgeApp(
 	authSubspace := app.ParamsKeeper.Subspace(auth.DefaultParamspace)
 	bankSubspace := app.ParamsKeeper.Subspace(bank.DefaultParamspace)
 	stakingSubspace := app.ParamsKeeper.Subspace(staking.DefaultParamspace)
+	slashingSubspace := app.ParamsKeeper.Subspace(slashing.DefaultParamspace)
+	govSubspace := app.ParamsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
+	oracleSubspace := app.ParamsKeeper.Subspace(oracle.DefaultParamspace)
+	ethbridgeSubspace := app.ParamsKeeper.Subspace(ethbridge.DefaultParamspace)
+	crisisSubspace := app.ParamsKeeper.Subspace(crisis.DefaultParamspace)
 
 	// add keepers
 	app.AccountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
 	app.BankKeeper = bank.NewBaseKeeper(app.AccountKeeper, bankSubspace, app.ModuleAccountAddrs())
 	app.SupplyKeeper = supply.NewKeeper(app.cdc, keys[supply.StoreKey], app.AccountKeeper, app.BankKeeper, maccPerms)
-	app.StakingKeeper = staking.NewKeeper(app.cdc, keys[staking.StoreKey],
+	stakingKeeper := staking.NewKeeper(app.cdc, keys[staking.StoreKey],
 		app.SupplyKeeper, stakingSubspace)
-	app.OracleKeeper = oracle.NewKeeper(app.cdc, keys[oracle.StoreKey],
-		app.StakingKeeper, oracle.DefaultConsensusNeeded,
+	app.SlashingKeeper = slashing.NewKeeper(app.cdc, keys[slashing.StoreKey], &stakingKeeper, slashingSubspace)
+	oracleKeeper := oracle.NewKeeper(app.cdc, keys[oracle.StoreKey], oracleSubspace, stakingKeeper,
+		app.SlashingKeeper)
+	app.BridgeKeeper = ethbridge.NewKeeper(app.cdc, keys[ethbridge.StoreKey], ethbridgeSubspace, app.SupplyKeeper,
+		&oracleKeeper)
+	app.CrisisKeeper = crisis.NewKeeper(crisisSubspace, invCheckPeriod, app.SupplyKeeper, auth.FeeCollectorName)
+	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
+	app.registerUpgradeHandlers()
+
+	// register the oracle namespaces and hooks, which let ethbridge process the claims of its prophecies once they
+	// succeed
+	app.OracleKeeper = *oracleKeeper.RegisterNamespace(
+		ethbridge.ModuleName, ethbridge.OracleClaimContentType{},
+	).SetHooks(
+		oracle.NewMultiOracleHooks(app.BridgeKeeper.Hooks()),
+	)
+
+	// register the governance proposal routes, which let governance change parameters, plan software upgrades and
+	// resolve bridge prophecies that are stuck or wrong
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(ethbridge.RouterKey, ethbridge.NewProposalHandler(app.BridgeKeeper))
+	app.GovKeeper = gov.NewKeeper(app.cdc, keys[gov.StoreKey], govSubspace, app.SupplyKeeper, &stakingKeeper,
+		govRouter)
+
+	// register the staking hooks, which let slashing track validator signing info and the oracle re-tally
+	// pending prophecies after validator set changes
+	app.StakingKeeper = *stakingKeeper.SetHooks(
+		staking.NewMultiStakingHooks(app.SlashingKeeper.Hooks(), app.OracleKeeper.Hooks()),
 	)
-	app.BridgeKeeper = ethbridge.NewKeeper(app.cdc, app.SupplyKeeper, app.OracleKeeper)
 
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
@@ -146,20 +202,35 @@ func NewEthereumBridgeApp(
 		auth.NewAppModule(app.AccountKeeper),
 		bank.NewAppModule(app.BankKeeper, app.AccountKeeper),
 		supply.NewAppModule(app.SupplyKeeper, app.AccountKeeper),
+		crisis.NewAppModule(&app.CrisisKeeper),
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
+		slashing.NewAppModule(app.SlashingKeeper, app.AccountKeeper, app.StakingKeeper),
+		gov.NewAppModule(app.GovKeeper, app.AccountKeeper, app.SupplyKeeper),
 		oracle.NewAppModule(app.OracleKeeper),
 		ethbridge.NewAppModule(app.OracleKeeper, app.SupplyKeeper, app.AccountKeeper, app.BridgeKeeper, app.cdc),
+		upgrade.NewAppModule(app.UpgradeKeeper),
 	)
 
-	app.mm.SetOrderEndBlockers(staking.ModuleName)
+	// NOTE: The upgrade module must occur first so that upgrades are applied before any other module reads the
+	// state they migrate.
+	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, staking.ModuleName, slashing.ModuleName)
+
+	app.mm.SetOrderEndBlockers(
+		crisis.ModuleName, staking.ModuleName, gov.ModuleName, oracle.ModuleName, ethbridge.ModuleName,
+	)
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
+	// NOTE: The crisis module must occur last as it asserts the invariants of the initialized state.
 	app.mm.SetOrderInitGenesis(
-		auth.ModuleName, staking.ModuleName, bank.ModuleName,
-		supply.ModuleName, genutil.ModuleName, ethbridge.ModuleName,
+		auth.ModuleName, staking.ModuleName, slashing.ModuleName, bank.ModuleName,
+		supply.ModuleName, gov.ModuleName, genutil.ModuleName, oracle.ModuleName, ethbridge.ModuleName,
+		crisis.ModuleName,
 	)
 
+	// register the invariants of the modules, which the crisis module asserts to halt the chain when they break
+	app.mm.RegisterInvariants(&app.CrisisKeeper)
+
 	// TODO: add simulator support
 
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
@@ -192,8 +263,13 @@ func (app *EthereumBridgeApp) InitChainer(ctx sdk.Context, req abci.RequestInitC
 	return app.mm.InitGenesis(ctx, genesisState)
 }
 
-// BeginBlocker application updates every begin block
+// BeginBlocker application updates every begin block. The first block processed since the app was loaded applies
+// the upgrade from the first release of the bridge if the chain still runs it, see applyFirstReleaseUpgrade.
 func (app *EthereumBridgeApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	if !app.upgradeChecked {
+		app.applyFirstReleaseUpgrade(ctx)
+		app.upgradeChecked = true
+	}
 	return app.mm.BeginBlock(ctx, req)
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 
 	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/sifchain/peggy/x/oracle"
 )
 
 // ExportAppStateAndValidators export the state of the eth peg-zone for a genesis file
@@ -18,6 +19,12 @@ func (app *EthereumBridgeApp) ExportAppStateAndValidators(forZeroHeight bool, ja
 	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
 
 	genState := app.mm.ExportGenesis(ctx)
+	if forZeroHeight {
+		// prophecies record absolute heights, which would lie ahead of the chain restarting from height zero
+		var oracleGenState oracle.GenesisState
+		oracle.ModuleCdc.MustUnmarshalJSON(genState[oracle.ModuleName], &oracleGenState)
+		genState[oracle.ModuleName] = oracle.ModuleCdc.MustMarshalJSON(oracleGenState.RebaseHeights(ctx.BlockHeight()))
+	}
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+
+	"github.com/sifchain/peggy/x/ethbridge"
+	"github.com/sifchain/peggy/x/oracle"
+)
+
+// firstReleaseUpgradeName is the name of the upgrade from the first release of the bridge to this version
+const firstReleaseUpgradeName = "peggy-v2"
+
+// registerUpgradeHandlers registers the handlers of the upgrades to this version
+func (app *EthereumBridgeApp) registerUpgradeHandlers() {
+	app.UpgradeKeeper.SetUpgradeHandler(firstReleaseUpgradeName, app.upgradeFromFirstRelease)
+}
+
+// upgradeFromFirstRelease initializes the state of the modules wired in since the first release of the bridge and
+// migrates the oracle and ethbridge state it stored
+func (app *EthereumBridgeApp) upgradeFromFirstRelease(ctx sdk.Context, plan upgrade.Plan) {
+	// the modules the first release did not run start from their default genesis state, the validators bonded
+	// before the upgrade have their downtime tracked from it
+	gov.InitGenesis(ctx, app.GovKeeper, app.SupplyKeeper, gov.DefaultGenesisState())
+	crisis.InitGenesis(ctx, app.CrisisKeeper, crisis.DefaultGenesisState())
+	slashing.InitGenesis(ctx, app.SlashingKeeper, app.StakingKeeper, slashing.DefaultGenesisState())
+	app.StakingKeeper.IterateBondedValidatorsByPower(ctx,
+		func(_ int64, validator stakingexported.ValidatorI) bool {
+			consAddr := validator.GetConsAddr()
+			app.SlashingKeeper.SetValidatorSigningInfo(ctx, consAddr,
+				slashing.NewValidatorSigningInfo(consAddr, ctx.BlockHeight(), 0, time.Unix(0, 0), false, 0))
+			return false
+		},
+	)
+
+	app.OracleKeeper.SetParams(ctx, oracle.DefaultParams())
+	app.BridgeKeeper.SetParams(ctx, ethbridge.DefaultParams())
+
+	migrated := app.BridgeKeeper.MigrateProphecies(ctx)
+	app.BridgeKeeper.SetBridgedCoinsFromBalances(ctx)
+	app.Logger().Info("upgraded from the first release of the bridge", "height", plan.Height,
+		"prophecies", migrated, "locked", app.BridgeKeeper.GetLockedCoins(ctx).String(),
+		"pegged", app.BridgeKeeper.GetPeggedCoins(ctx).String())
+
+	app.CrisisKeeper.AssertInvariants(ctx)
+}
+
+// applyFirstReleaseUpgrade applies the upgrade from the first release of the bridge at the current height if the
+// chain still runs it. The first release had no upgrade module to plan the upgrade with, its chains are halted with
+// --halt-height and restarted with this version. It is recognized by the governance parameters, which chains
+// started from this version set at genesis.
+func (app *EthereumBridgeApp) applyFirstReleaseUpgrade(ctx sdk.Context) {
+	govSubspace, _ := app.ParamsKeeper.GetSubspace(gov.DefaultParamspace)
+	if govSubspace.Has(ctx, gov.ParamStoreKeyDepositParams) {
+		return
+	}
+	app.UpgradeKeeper.ApplyUpgrade(ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()),
+		upgrade.Plan{Name: firstReleaseUpgradeName, Height: ctx.BlockHeight()})
+}
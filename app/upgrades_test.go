@@ -0,0 +1,71 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+
+	"github.com/sifchain/peggy/x/ethbridge"
+	"github.com/sifchain/peggy/x/oracle"
+)
+
+func TestUpgradeFromFirstRelease(t *testing.T) {
+	db := dbm.NewMemDB()
+	app := NewEthereumBridgeApp(log.NewNopLogger(), db, true, map[int64]bool{}, 0)
+
+	// chains running the first release have no state for the modules wired in since
+	genesisState := NewDefaultGenesisState()
+	for _, moduleName := range []string{gov.ModuleName, slashing.ModuleName, crisis.ModuleName} {
+		delete(genesisState, moduleName)
+	}
+	stateBytes, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+
+	// and store their prophecies under their legacy id
+	ctx := app.NewContext(false, abci.Header{})
+	sender := ethbridge.NewEthereumAddress("0x7B95B6EC7EbD73572298cEf32Bb54FA408207359")
+	legacyID := ethbridge.LegacyProphecyID(3, 12, sender)
+	ctx.KVStore(app.keys[oracle.StoreKey]).Set([]byte(legacyID), app.cdc.MustMarshalBinaryBare(
+		oracle.LegacyDBProphecy{
+			ID:              legacyID,
+			Status:          oracle.NewStatus(oracle.PendingStatusText, ""),
+			ClaimValidators: []byte("{}"),
+			ValidatorClaims: []byte("{}"),
+		},
+	))
+
+	// the first block applies the upgrade
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx = app.NewContext(false, header)
+	require.Equal(t, int64(1), app.UpgradeKeeper.GetDoneHeight(ctx, firstReleaseUpgradeName))
+	require.Equal(t, gov.DefaultGenesisState().DepositParams, app.GovKeeper.GetDepositParams(ctx))
+	require.Equal(t, slashing.DefaultParams(), app.SlashingKeeper.GetParams(ctx))
+	require.Equal(t, crisis.DefaultGenesisState().ConstantFee, app.CrisisKeeper.GetConstantFee(ctx))
+	require.Equal(t, oracle.DefaultParams(), app.OracleKeeper.GetParams(ctx))
+	require.True(t, app.BridgeKeeper.GetLockedCoins(ctx).Empty())
+
+	// no bridge contract is configured, the prophecy keeps its legacy id in the ethbridge namespace
+	require.False(t, ctx.KVStore(app.keys[oracle.StoreKey]).Has([]byte(legacyID)))
+	require.True(t, app.OracleKeeper.HasProphecy(ctx, ethbridge.ModuleName, legacyID))
+	require.True(t, ctx.KVStore(app.keys[ethbridge.StoreKey]).Has(ethbridge.AmbiguousEventKey(3, 12, sender)))
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	// apps loaded later do not apply it again
+	app = NewEthereumBridgeApp(log.NewNopLogger(), db, true, map[int64]bool{}, 0)
+	header = abci.Header{Height: 2}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx = app.NewContext(false, header)
+	require.Equal(t, int64(1), app.UpgradeKeeper.GetDoneHeight(ctx, firstReleaseUpgradeName))
+	require.True(t, app.OracleKeeper.HasProphecy(ctx, ethbridge.ModuleName, legacyID))
+}
@@ -23,6 +23,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 )
 
+const flagInvCheckPeriod = "inv-check-period"
+
+var invCheckPeriod uint
+
 func main() {
 	cdc := app.MakeCodec()
 
@@ -53,14 +57,21 @@ func main() {
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "EB", app.DefaultNodeHome)
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
+		0, "Assert registered invariants every N blocks")
 	if err := executor.Execute(); err != nil {
 		panic(err)
 	}
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
+	skipUpgradeHeights := make(map[int64]bool)
+	for _, h := range viper.GetIntSlice(server.FlagUnsafeSkipUpgrades) {
+		skipUpgradeHeights[int64(h)] = true
+	}
+
 	return app.NewEthereumBridgeApp(
-		logger, db, true,
+		logger, db, true, skipUpgradeHeights, invCheckPeriod,
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
 	)
@@ -71,12 +82,12 @@ func exportAppStateAndTMValidators(
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 
 	if height != -1 {
-		ebApp := app.NewEthereumBridgeApp(logger, db, false)
+		ebApp := app.NewEthereumBridgeApp(logger, db, false, map[int64]bool{}, uint(1))
 		if err := ebApp.LoadHeight(height); err != nil {
 			return nil, nil, err
 		}
 		return ebApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
-	ebApp := app.NewEthereumBridgeApp(logger, db, true)
+	ebApp := app.NewEthereumBridgeApp(logger, db, true, map[int64]bool{}, uint(1))
 	return ebApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
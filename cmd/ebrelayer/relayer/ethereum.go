@@ -208,6 +208,9 @@ func (sub EthereumSub) handleEthereumEvent(clientChainID *big.Int, contractAddre
 	}
 	event.BridgeContractAddress = contractAddress
 	event.EthereumChainID = clientChainID
+	event.TxHash = cLog.TxHash
+	event.BlockNumber = cLog.BlockNumber
+	event.LogIndex = uint64(cLog.Index)
 	if eventName == types.LogBurn.String() {
 		event.ClaimType = ethbridge.BurnText
 	} else {
@@ -222,6 +225,8 @@ func (sub EthereumSub) handleEthereumEvent(clientChainID *big.Int, contractAddre
 	if err != nil {
 		return err
 	}
+	sub.Logger.Info(fmt.Sprintf("Relaying claim on prophecy %s", ethbridge.ProphecyID(prophecyClaim.EthereumChainID,
+		prophecyClaim.BridgeContractAddress, prophecyClaim.Nonce, prophecyClaim.EthereumSender)))
 	return txs.RelayToCosmos(sub.Cdc, sub.ValidatorName, &prophecyClaim, sub.CliCtx, sub.TxBldr)
 }
 
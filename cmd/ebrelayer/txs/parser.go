@@ -26,7 +26,10 @@ const (
 func EthereumEventToEthBridgeClaim(valAddr sdk.ValAddress, event *types.EthereumEvent) (ethbridge.EthBridgeClaim, error) {
 	witnessClaim := ethbridge.EthBridgeClaim{}
 
-	// chainID type casting (*big.Int -> int)
+	// chainID type casting (*big.Int -> int), chain ids that do not fit are rejected rather than truncated
+	if event.EthereumChainID == nil || event.EthereumChainID.Sign() <= 0 || !event.EthereumChainID.IsInt64() {
+		return witnessClaim, fmt.Errorf("invalid chain id: %v", event.EthereumChainID)
+	}
 	chainID := int(event.EthereumChainID.Int64())
 
 	bridgeContractAddress := ethbridge.NewEthereumAddress(event.BridgeContractAddress.Hex())
@@ -61,9 +64,20 @@ func EthereumEventToEthBridgeClaim(valAddr sdk.ValAddress, event *types.Ethereum
 		symbol = strings.Join(res[1:], "")
 	}
 
-	amount := event.Value.Int64()
+	// Amount type casting (*big.Int -> sdk.Int), uint256 amounts of 2^255 and more do not fit an sdk.Int and are
+	// rejected
+	if event.Value == nil || event.Value.Sign() <= 0 {
+		return witnessClaim, fmt.Errorf("invalid amount: %v", event.Value)
+	}
+	amount, ok := sdk.NewIntFromString(event.Value.String())
+	if !ok {
+		return witnessClaim, fmt.Errorf("amount %s overflows", event.Value)
+	}
 
-	// Nonce type casting (*big.Int -> int)
+	// Nonce type casting (*big.Int -> int), nonces that do not fit are rejected rather than truncated
+	if event.Nonce == nil || event.Nonce.Sign() < 0 || !event.Nonce.IsInt64() {
+		return witnessClaim, fmt.Errorf("invalid nonce: %v", event.Nonce)
+	}
 	nonce := int(event.Nonce.Int64())
 
 	// Package the information in a unique EthBridgeClaim
@@ -77,6 +91,9 @@ func EthereumEventToEthBridgeClaim(valAddr sdk.ValAddress, event *types.Ethereum
 	witnessClaim.CosmosReceiver = recipient
 	witnessClaim.Amount = amount
 	witnessClaim.ClaimType = event.ClaimType
+	witnessClaim.EthereumTxHash = ethbridge.NewEthereumTxHash(event.TxHash.Hex())
+	witnessClaim.EthereumBlockNumber = event.BlockNumber
+	witnessClaim.EthereumLogIndex = event.LogIndex
 
 	return witnessClaim, nil
 }
@@ -31,7 +31,8 @@ func TestLogLockToEthBridgeClaim(t *testing.T) {
 	// Set up expected EthBridgeClaim
 	expectedEthBridgeClaim := ethbridge.NewEthBridgeClaim(
 		TestEthereumChainID, testBridgeContractAddress, TestNonce, strings.ToLower(TestSymbol), testTokenContractAddress,
-		testEthereumAddress, testCosmosAddress, testCosmosValidatorBech32Address, TestAmount, TestLockClaimType)
+		testEthereumAddress, testCosmosAddress, testCosmosValidatorBech32Address, sdk.NewInt(TestAmount), TestLockClaimType,
+		ethbridge.NewEthereumTxHash(TestTxHash), TestBlockNumber, TestLogIndex)
 
 	// Create test ethereum event
 	ethereumEvent := CreateTestLogEthereumEvent(t)
@@ -42,6 +43,76 @@ func TestLogLockToEthBridgeClaim(t *testing.T) {
 	require.Equal(t, expectedEthBridgeClaim, ethBridgeClaim)
 }
 
+func TestLogLockToEthBridgeClaimAmounts(t *testing.T) {
+	testRawCosmosValidatorAddress, err := sdk.AccAddressFromBech32(TestCosmosAddress2)
+	require.NoError(t, err)
+	testCosmosValidatorBech32Address := sdk.ValAddress(testRawCosmosValidatorAddress)
+	ethereumEvent := CreateTestLogEthereumEvent(t)
+
+	// 100 tokens of 18 decimals do not fit in an int64 but are relayed exactly
+	amount, ok := new(big.Int).SetString("100000000000000000000", 10)
+	require.True(t, ok)
+	ethereumEvent.Value = amount
+	ethBridgeClaim, err := EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.NoError(t, err)
+	require.Equal(t, "100000000000000000000", ethBridgeClaim.Amount.String())
+
+	// sdk.Ints hold at most 255 bits, larger uint256 amounts are rejected rather than truncated
+	maxAmount := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	ethereumEvent.Value = maxAmount
+	ethBridgeClaim, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.NoError(t, err)
+	require.Equal(t, maxAmount.String(), ethBridgeClaim.Amount.String())
+
+	for _, tooLarge := range []*big.Int{
+		new(big.Int).Lsh(big.NewInt(1), 255),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+	} {
+		ethereumEvent.Value = tooLarge
+		_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+		require.Error(t, err)
+	}
+
+	ethereumEvent.Value = big.NewInt(0)
+	_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.Error(t, err)
+}
+
+func TestLogLockToEthBridgeClaimNonces(t *testing.T) {
+	testRawCosmosValidatorAddress, err := sdk.AccAddressFromBech32(TestCosmosAddress2)
+	require.NoError(t, err)
+	testCosmosValidatorBech32Address := sdk.ValAddress(testRawCosmosValidatorAddress)
+	ethereumEvent := CreateTestLogEthereumEvent(t)
+
+	// uint256 nonces that do not fit an int are rejected rather than truncated
+	ethereumEvent.Nonce = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(TestNonce))
+	_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.Error(t, err)
+
+	ethereumEvent.Nonce = nil
+	_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.Error(t, err)
+}
+
+func TestLogLockToEthBridgeClaimChainIDs(t *testing.T) {
+	testRawCosmosValidatorAddress, err := sdk.AccAddressFromBech32(TestCosmosAddress2)
+	require.NoError(t, err)
+	testCosmosValidatorBech32Address := sdk.ValAddress(testRawCosmosValidatorAddress)
+	ethereumEvent := CreateTestLogEthereumEvent(t)
+
+	// uint256 chain ids that do not fit an int are rejected rather than truncated
+	ethereumEvent.EthereumChainID = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64),
+		big.NewInt(TestEthereumChainID))
+	_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+	require.Error(t, err)
+
+	for _, chainID := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		ethereumEvent.EthereumChainID = chainID
+		_, err = EthereumEventToEthBridgeClaim(testCosmosValidatorBech32Address, &ethereumEvent)
+		require.Error(t, err)
+	}
+}
+
 func TestProphecyClaimToSignedOracleClaim(t *testing.T) {
 	// Set ETHEREUM_PRIVATE_KEY env variable
 	os.Setenv(EthereumPrivateKey, TestPrivHex)
@@ -36,6 +36,9 @@ const (
 	TestPrivHex               = "289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032"
 	TestNullAddress           = "0x0000000000000000000000000000000000000000"
 	TestOtherAddress          = "0x1000000000000000000000000000000000000000"
+	TestTxHash                = "0x9e6ba5ba4e5d3ee1b1c6c2aaae4ae7bba6cf50fa5c17ec1e9caea5bbc81de1f2"
+	TestBlockNumber           = 12
+	TestLogIndex              = 1
 )
 
 // CreateTestLogEthereumEvent creates a sample EthereumEvent event for testing purposes
@@ -54,9 +57,21 @@ func CreateTestLogEthereumEvent(t *testing.T) types.EthereumEvent {
 	testAmount := big.NewInt(int64(TestAmount))
 	testNonce := big.NewInt(int64(TestNonce))
 
-	return types.EthereumEvent{testEthereumChainID, testBridgeContractAddress,
-		testProphecyID32, testEthereumSender, testCosmosRecipient, testTokenAddress,
-		TestSymbol, testAmount, testNonce, ethbridge.LockText}
+	return types.EthereumEvent{
+		EthereumChainID:       testEthereumChainID,
+		BridgeContractAddress: testBridgeContractAddress,
+		ID:                    testProphecyID32,
+		From:                  testEthereumSender,
+		To:                    testCosmosRecipient,
+		Token:                 testTokenAddress,
+		Symbol:                TestSymbol,
+		Value:                 testAmount,
+		Nonce:                 testNonce,
+		ClaimType:             ethbridge.LockText,
+		TxHash:                common.HexToHash(TestTxHash),
+		BlockNumber:           TestBlockNumber,
+		LogIndex:              TestLogIndex,
+	}
 }
 
 // CreateTestProphecyClaimEvent creates a sample ProphecyClaimEvent for testing purposes
@@ -44,14 +44,18 @@ type EthereumEvent struct {
 	Value                 *big.Int
 	Nonce                 *big.Int
 	ClaimType             ethbridge.ClaimType
+	TxHash                common.Hash
+	BlockNumber           uint64
+	LogIndex              uint64
 }
 
 // String implements fmt.Stringer
 func (e EthereumEvent) String() string {
 	return fmt.Sprintf("\nChain ID: %v\nBridge contract address: %v\nToken symbol: %v\nToken "+
-		"contract address: %v\nSender: %v\nRecipient: %v\nValue: %v\nNonce: %v\nClaim type: %v",
+		"contract address: %v\nSender: %v\nRecipient: %v\nValue: %v\nNonce: %v\nClaim type: %v\nTx hash: %v\n"+
+		"Block number: %v\nLog index: %v",
 		e.EthereumChainID, e.BridgeContractAddress.Hex(), e.Symbol, e.Token.Hex(), e.From.Hex(),
-		string(e.To), e.Value, e.Nonce, e.ClaimType.String())
+		string(e.To), e.Value, e.Nonce, e.ClaimType.String(), e.TxHash.Hex(), e.BlockNumber, e.LogIndex)
 }
 
 // ProphecyClaimEvent struct which represents a LogNewProphecyClaim event
@@ -0,0 +1,56 @@
+package ethbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
+	keeperLib "github.com/sifchain/peggy/x/oracle/keeper"
+)
+
+func TestRetalliedProphecies(t *testing.T) {
+	input := oracle.CreateTestInput(t, 0.7, []int64{3, 7}, ModuleName)
+	ctx, stakingKeeper := input.Ctx, input.StakingKeeper
+	stakingKeeper.SetHooks(input.OracleKeeper.Hooks())
+
+	cdc := keeperLib.MakeTestCodec()
+	oracleKeeper := input.OracleKeeper
+	bridgeKeeper := NewKeeper(cdc, input.ModuleStoreKey, input.ParamsKeeper.Subspace(DefaultParamspace),
+		input.SupplyKeeper, &oracleKeeper)
+	oracleKeeper.RegisterNamespace(ModuleName, OracleClaimContentType{}).SetHooks(bridgeKeeper.Hooks())
+	InitGenesis(ctx, bridgeKeeper, input.SupplyKeeper, NewGenesisState(types.CreateTestParams(), sdk.Coins{}, sdk.Coins{}))
+	handler := NewHandler(input.AccountKeeper, bridgeKeeper, cdc)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow7 := input.ValidatorAddresses[1]
+
+	_, err := handler(ctx, types.CreateTestEthMsg(t, validator1Pow3, types.LockText))
+	require.NoError(t, err)
+
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	require.True(t, input.BankKeeper.GetCoins(ctx, receiverAddress).IsZero())
+
+	// the pending claim fails once the powerful validator leaves the validator set, as the power snapshotted for
+	// it can no longer be claimed
+	validator2, found := stakingKeeper.GetValidator(ctx, validator2Pow7)
+	require.True(t, found)
+	stakingKeeper.Jail(ctx, validator2.GetConsAddr())
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	oracle.EndBlocker(ctx, oracleKeeper)
+
+	require.True(t, input.BankKeeper.GetCoins(ctx, receiverAddress).IsZero())
+
+	events := ctx.EventManager().Events()
+	statusEvent := events[len(events)-1]
+	require.Equal(t, oracle.EventTypeProphecyRetallied, statusEvent.Type)
+	require.Contains(t, statusEvent.Attributes, sdk.NewAttribute(oracle.AttributeKeyNamespace, ModuleName).ToKVPair())
+	require.Contains(t, statusEvent.Attributes,
+		sdk.NewAttribute(oracle.AttributeKeyStatus, oracle.FailedStatusText.String()).ToKVPair())
+}
@@ -12,32 +12,77 @@ import (
 )
 
 const (
-	QueryEthProphecy = types.QueryEthProphecy
-	ModuleName       = types.ModuleName
-	StoreKey         = types.StoreKey
-	QuerierRoute     = types.QuerierRoute
-	RouterKey        = types.RouterKey
+	QueryEthProphecy           = types.QueryEthProphecy
+	QueryEthPropheciesByTxHash = types.QueryEthPropheciesByTxHash
+	ModuleName                 = types.ModuleName
+	StoreKey                   = types.StoreKey
+	QuerierRoute               = types.QuerierRoute
+	RouterKey                  = types.RouterKey
+
+	DefaultParamspace       = types.DefaultParamspace
+	DefaultPeggedCoinPrefix = types.DefaultPeggedCoinPrefix
+
+	ProposalTypeResolveProphecy = types.ProposalTypeResolveProphecy
 )
 
 var (
 	// functions aliases
 
-	NewKeeper                         = keeper.NewKeeper
-	NewQuerier                        = keeper.NewQuerier
-	NewEthBridgeClaim                 = types.NewEthBridgeClaim
-	NewOracleClaimContent             = types.NewOracleClaimContent
-	CreateOracleClaimFromEthClaim     = types.CreateOracleClaimFromEthClaim
-	CreateEthClaimFromOracleString    = types.CreateEthClaimFromOracleString
-	CreateOracleClaimFromOracleString = types.CreateOracleClaimFromOracleString
-	RegisterCodec                     = types.RegisterCodec
-	ErrInvalidEthNonce                = types.ErrInvalidEthNonce
-	ErrInvalidEthAddress              = types.ErrInvalidEthAddress
-	ErrJSONMarshalling                = types.ErrJSONMarshalling
-	NewEthereumAddress                = types.NewEthereumAddress
-	NewMsgCreateEthBridgeClaim        = types.NewMsgCreateEthBridgeClaim
-	MapOracleClaimsToEthBridgeClaims  = types.MapOracleClaimsToEthBridgeClaims
-	NewQueryEthProphecyParams         = types.NewQueryEthProphecyParams
-	NewQueryEthProphecyResponse       = types.NewQueryEthProphecyResponse
+	NewKeeper                             = keeper.NewKeeper
+	NewQuerier                            = keeper.NewQuerier
+	RegisterInvariants                    = keeper.RegisterInvariants
+	AllInvariants                         = keeper.AllInvariants
+	EscrowInvariant                       = keeper.EscrowInvariant
+	PeggedSupplyInvariant                 = keeper.PeggedSupplyInvariant
+	NewEthBridgeClaim                     = types.NewEthBridgeClaim
+	NewOracleClaimContent                 = types.NewOracleClaimContent
+	CreateOracleClaimFromEthClaim         = types.CreateOracleClaimFromEthClaim
+	ProphecyID                            = types.ProphecyID
+	AmbiguousEventKey                     = types.AmbiguousEventKey
+	LegacyProphecyID                      = types.LegacyProphecyID
+	EthBridgeClaimCommitment              = types.EthBridgeClaimCommitment
+	CreateEthClaimFromOracleString        = types.CreateEthClaimFromOracleString
+	CreateOracleClaimFromOracleString     = types.CreateOracleClaimFromOracleString
+	RegisterCodec                         = types.RegisterCodec
+	NewGenesisState                       = types.NewGenesisState
+	DefaultGenesisState                   = types.DefaultGenesisState
+	ValidateGenesis                       = types.ValidateGenesis
+	NewParams                             = types.NewParams
+	DefaultParams                         = types.DefaultParams
+	ParamKeyTable                         = types.ParamKeyTable
+	NewRegisteredToken                    = types.NewRegisteredToken
+	ErrInvalidEthNonce                    = types.ErrInvalidEthNonce
+	ErrInvalidEthAddress                  = types.ErrInvalidEthAddress
+	ErrJSONMarshalling                    = types.ErrJSONMarshalling
+	ErrInvalidSalt                        = types.ErrInvalidSalt
+	ErrInvalidEthTxHash                   = types.ErrInvalidEthTxHash
+	ErrInvalidEthBlockNumber              = types.ErrInvalidEthBlockNumber
+	ErrUnregisteredToken                  = types.ErrUnregisteredToken
+	ErrTokenDisabled                      = types.ErrTokenDisabled
+	ErrInsufficientEscrow                 = types.ErrInsufficientEscrow
+	ErrInsufficientPeggedCoins            = types.ErrInsufficientPeggedCoins
+	ErrAmbiguousProphecyID                = types.ErrAmbiguousProphecyID
+	NewEthereumAddress                    = types.NewEthereumAddress
+	NewEthereumTxHash                     = types.NewEthereumTxHash
+	IsHexEthereumTxHash                   = types.IsHexEthereumTxHash
+	NewMsgCreateEthBridgeClaim            = types.NewMsgCreateEthBridgeClaim
+	NewMsgCommitEthBridgeClaim            = types.NewMsgCommitEthBridgeClaim
+	NewMsgRevealEthBridgeClaim            = types.NewMsgRevealEthBridgeClaim
+	NewMsgRejectEthBridgeClaim            = types.NewMsgRejectEthBridgeClaim
+	MapOracleClaimsToEthBridgeClaims      = types.MapOracleClaimsToEthBridgeClaims
+	NewQueryEthProphecyParams             = types.NewQueryEthProphecyParams
+	NewQueryEthProphecyResponse           = types.NewQueryEthProphecyResponse
+	NewQueryEthPropheciesByTxHashParams   = types.NewQueryEthPropheciesByTxHashParams
+	NewQueryEthPropheciesByTxHashResponse = types.NewQueryEthPropheciesByTxHashResponse
+	NewResolveProphecyProposal            = types.NewResolveProphecyProposal
+	HandleResolveProphecyProposal         = keeper.HandleResolveProphecyProposal
+
+	// variable aliases
+
+	ModuleCdc               = types.ModuleCdc
+	LockedCoinsKey          = types.LockedCoinsKey
+	PeggedCoinsKey          = types.PeggedCoinsKey
+	AmbiguousEventKeyPrefix = types.AmbiguousEventKeyPrefix
 
 	CreateTestEthMsg                   = types.CreateTestEthMsg
 	CreateTestEthClaim                 = types.CreateTestEthClaim
@@ -45,13 +90,24 @@ var (
 )
 
 type (
-	Keeper                   = keeper.Keeper
-	EthBridgeClaim           = types.EthBridgeClaim //nolint:golint
-	OracleClaimContent       = types.OracleClaimContent
-	EthereumAddress          = types.EthereumAddress
-	MsgCreateEthBridgeClaim  = types.MsgCreateEthBridgeClaim
-	MsgBurn                  = types.MsgBurn
-	MsgLock                  = types.MsgLock
-	QueryEthProphecyParams   = types.QueryEthProphecyParams
-	QueryEthProphecyResponse = types.QueryEthProphecyResponse
+	Keeper                             = keeper.Keeper
+	GenesisState                       = types.GenesisState
+	Params                             = types.Params
+	RegisteredToken                    = types.RegisteredToken
+	EthBridgeClaim                     = types.EthBridgeClaim //nolint:golint
+	OracleClaimContent                 = types.OracleClaimContent
+	OracleClaimContentType             = types.OracleClaimContentType
+	EthereumAddress                    = types.EthereumAddress
+	EthereumTxHash                     = types.EthereumTxHash
+	MsgCreateEthBridgeClaim            = types.MsgCreateEthBridgeClaim
+	MsgCommitEthBridgeClaim            = types.MsgCommitEthBridgeClaim
+	MsgRevealEthBridgeClaim            = types.MsgRevealEthBridgeClaim
+	MsgRejectEthBridgeClaim            = types.MsgRejectEthBridgeClaim
+	MsgBurn                            = types.MsgBurn
+	MsgLock                            = types.MsgLock
+	QueryEthProphecyParams             = types.QueryEthProphecyParams
+	QueryEthProphecyResponse           = types.QueryEthProphecyResponse
+	QueryEthPropheciesByTxHashParams   = types.QueryEthPropheciesByTxHashParams
+	QueryEthPropheciesByTxHashResponse = types.QueryEthPropheciesByTxHashResponse
+	ResolveProphecyProposal            = types.ResolveProphecyProposal
 )
@@ -63,3 +63,39 @@ func GetCmdGetEthBridgeProphecy(queryRoute string, cdc *codec.Codec) *cobra.Comm
 		},
 	}
 }
+
+// GetCmdGetEthBridgePropheciesByTxHash queries the prophecies claimed on an ethereum transaction
+func GetCmdGetEthBridgePropheciesByTxHash(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prophecies-by-tx-hash [ethereum-tx-hash]",
+		Short: "Query the prophecies claimed on an ethereum transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if !types.IsHexEthereumTxHash(args[0]) {
+				return types.ErrInvalidEthTxHash
+			}
+			ethereumTxHash := types.NewEthereumTxHash(args[0])
+
+			bz, err := cdc.MarshalJSON(types.NewQueryEthPropheciesByTxHashParams(ethereumTxHash))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryEthPropheciesByTxHash)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryEthPropheciesByTxHashResponse
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
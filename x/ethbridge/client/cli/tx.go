@@ -2,8 +2,9 @@ package cli
 
 import (
 	"bufio"
-	"regexp"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -22,8 +25,8 @@ import (
 // GetCmdCreateEthBridgeClaim is the CLI command for creating a claim on an ethereum prophecy
 //nolint:lll
 func GetCmdCreateEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "create-claim [bridge-registry-contract] [nonce] [symbol] [ethereum-sender-address] [cosmos-receiver-address] [validator-address] [amount] [claim-type] --ethereum-chain-id [ethereum-chain-id] --token-contract-address [token-contract-address]",
+	cmd := &cobra.Command{
+		Use:   "create-claim [bridge-registry-contract] [nonce] [symbol] [ethereum-sender-address] [cosmos-receiver-address] [validator-address] [amount] [claim-type] --ethereum-chain-id [ethereum-chain-id] --token-contract-address [token-contract-address] --ethereum-tx-hash [ethereum-tx-hash] --ethereum-block-number [ethereum-block-number] --ethereum-log-index [ethereum-log-index]",
 		Short: "create a claim on an ethereum prophecy",
 		Args:  cobra.ExactArgs(8),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,64 +35,140 @@ func GetCmdCreateEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := authtypes.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			ethereumChainIDString := viper.GetString(types.FlagEthereumChainID)
-			ethereumChainID, err := strconv.Atoi(ethereumChainIDString)
+			ethBridgeClaim, err := parseEthBridgeClaim(args)
 			if err != nil {
 				return err
 			}
 
-			tokenContractString := viper.GetString(types.FlagTokenContractAddr)
-			if !common.IsHexAddress(tokenContractString) {
-				return errors.Errorf("invalid [token-contract-address]: %s", tokenContractString)
+			msg := types.NewMsgCreateEthBridgeClaim(ethBridgeClaim)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
-			tokenContract := types.NewEthereumAddress(tokenContractString)
 
-			if !common.IsHexAddress(args[0]) {
-				return errors.Errorf("invalid [bridge-registry-contract]: %s", args[0])
-			}
-			bridgeContract := types.NewEthereumAddress(args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	addEthereumLogFlags(cmd)
+	return cmd
+}
 
-			nonce, err := strconv.Atoi(args[1])
+// GetCmdCommitEthBridgeClaim is the CLI command for committing to a claim on an ethereum prophecy
+//nolint:lll
+func GetCmdCommitEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit-claim [bridge-registry-contract] [nonce] [symbol] [ethereum-sender-address] [cosmos-receiver-address] [validator-address] [amount] [claim-type] --ethereum-chain-id [ethereum-chain-id] --token-contract-address [token-contract-address] --ethereum-tx-hash [ethereum-tx-hash] --ethereum-block-number [ethereum-block-number] --ethereum-log-index [ethereum-log-index] --salt [salt]",
+		Short: "commit to a claim on an ethereum prophecy",
+		Long: `Commit to a claim on an ethereum prophecy without publishing it. Only the commitment computed from the claim and the salt is sent.
+		Once the commit window of the prophecy closes, reveal the claim with the reveal-claim command and the same salt.`,
+		Args: cobra.ExactArgs(8),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtypes.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			ethBridgeClaim, err := parseEthBridgeClaim(args)
 			if err != nil {
 				return err
 			}
+			if err := types.NewMsgCreateEthBridgeClaim(ethBridgeClaim).ValidateBasic(); err != nil {
+				return err
+			}
 
-			symbol := args[2]
-			ethereumSender := types.NewEthereumAddress(args[3])
-			if !common.IsHexAddress(args[3]) {
-				return errors.Errorf("invalid [ethereum-sender-address]: %s", args[0])
+			salt := viper.GetString(types.FlagSalt)
+			if len(salt) == 0 {
+				return types.ErrInvalidSalt
 			}
-			cosmosReceiver, err := sdk.AccAddressFromBech32(args[4])
+			commitment, err := types.EthBridgeClaimCommitment(cdc, ethBridgeClaim, salt)
 			if err != nil {
 				return err
 			}
 
-			validator, err := sdk.ValAddressFromBech32(args[5])
+			msg := types.NewMsgCommitEthBridgeClaim(ethBridgeClaim.EthereumChainID, ethBridgeClaim.BridgeContractAddress,
+				ethBridgeClaim.Nonce, ethBridgeClaim.EthereumSender, ethBridgeClaim.ValidatorAddress, commitment)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	addEthereumLogFlags(cmd)
+	cmd.Flags().String(types.FlagSalt, "", "Secret salt the claim is committed with")
+	return cmd
+}
+
+// GetCmdRevealEthBridgeClaim is the CLI command for revealing a committed claim on an ethereum prophecy
+//nolint:lll
+func GetCmdRevealEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reveal-claim [bridge-registry-contract] [nonce] [symbol] [ethereum-sender-address] [cosmos-receiver-address] [validator-address] [amount] [claim-type] --ethereum-chain-id [ethereum-chain-id] --token-contract-address [token-contract-address] --ethereum-tx-hash [ethereum-tx-hash] --ethereum-block-number [ethereum-block-number] --ethereum-log-index [ethereum-log-index] --salt [salt]",
+		Short: "reveal a claim committed to on an ethereum prophecy",
+		Args:  cobra.ExactArgs(8),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtypes.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			ethBridgeClaim, err := parseEthBridgeClaim(args)
 			if err != nil {
 				return err
 			}
 
-			var digitCheck = regexp.MustCompile(`^[0-9]+$`)
-			if !digitCheck.MatchString(args[6]) {
-				return types.ErrInvalidAmount
+			msg := types.NewMsgRevealEthBridgeClaim(ethBridgeClaim, viper.GetString(types.FlagSalt))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
-			amount, err := strconv.ParseInt(args[6], 10, 64)
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	addEthereumLogFlags(cmd)
+	cmd.Flags().String(types.FlagSalt, "", "Secret salt the claim was committed with")
+	return cmd
+}
+
+// GetCmdRejectEthBridgeClaim is the CLI command for voting to reject an ethereum prophecy
+//nolint:lll
+func GetCmdRejectEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reject-claim [bridge-registry-contract] [nonce] [ethereum-sender-address] [validator-address] --ethereum-chain-id [ethereum-chain-id]",
+		Short: "vote to reject an ethereum prophecy",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtypes.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			ethereumChainIDString := viper.GetString(types.FlagEthereumChainID)
+			ethereumChainID, err := strconv.Atoi(ethereumChainIDString)
 			if err != nil {
 				return err
 			}
-			if amount <= 0 {
-				return types.ErrInvalidAmount
+
+			if !common.IsHexAddress(args[0]) {
+				return errors.Errorf("invalid [bridge-registry-contract]: %s", args[0])
 			}
+			bridgeContract := types.NewEthereumAddress(args[0])
 
-			claimType, err := types.StringToClaimType(args[7])
+			nonce, err := strconv.Atoi(args[1])
 			if err != nil {
 				return err
 			}
 
-			ethBridgeClaim := types.NewEthBridgeClaim(ethereumChainID, bridgeContract, nonce, symbol, tokenContract,
-				ethereumSender, cosmosReceiver, validator, amount, claimType)
+			if !common.IsHexAddress(args[2]) {
+				return errors.Errorf("invalid [ethereum-sender-address]: %s", args[2])
+			}
+			ethereumSender := types.NewEthereumAddress(args[2])
 
-			msg := types.NewMsgCreateEthBridgeClaim(ethBridgeClaim)
+			validator, err := sdk.ValAddressFromBech32(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRejectEthBridgeClaim(ethereumChainID, bridgeContract, nonce, ethereumSender, validator)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -99,6 +178,76 @@ func GetCmdCreateEthBridgeClaim(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// addEthereumLogFlags adds the flags locating the ethereum log of the claimed event to a claim command
+func addEthereumLogFlags(cmd *cobra.Command) {
+	cmd.Flags().String(types.FlagEthereumTxHash, "", "Hash of the ethereum transaction the event was emitted in")
+	cmd.Flags().Uint64(types.FlagEthereumBlockNumber, 0, "Number of the ethereum block the event was emitted in")
+	cmd.Flags().Uint64(types.FlagEthereumLogIndex, 0, "Index in its block of the ethereum log of the event")
+}
+
+// parseEthBridgeClaim parses the arguments and flags of the claim commands into an ethereum bridge claim
+func parseEthBridgeClaim(args []string) (types.EthBridgeClaim, error) {
+	ethereumChainIDString := viper.GetString(types.FlagEthereumChainID)
+	ethereumChainID, err := strconv.Atoi(ethereumChainIDString)
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	tokenContractString := viper.GetString(types.FlagTokenContractAddr)
+	if !common.IsHexAddress(tokenContractString) {
+		return types.EthBridgeClaim{}, errors.Errorf("invalid [token-contract-address]: %s", tokenContractString)
+	}
+	tokenContract := types.NewEthereumAddress(tokenContractString)
+
+	if !common.IsHexAddress(args[0]) {
+		return types.EthBridgeClaim{}, errors.Errorf("invalid [bridge-registry-contract]: %s", args[0])
+	}
+	bridgeContract := types.NewEthereumAddress(args[0])
+
+	nonce, err := strconv.Atoi(args[1])
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	symbol := args[2]
+	ethereumSender := types.NewEthereumAddress(args[3])
+	if !common.IsHexAddress(args[3]) {
+		return types.EthBridgeClaim{}, errors.Errorf("invalid [ethereum-sender-address]: %s", args[0])
+	}
+	cosmosReceiver, err := sdk.AccAddressFromBech32(args[4])
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	validator, err := sdk.ValAddressFromBech32(args[5])
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	amount, err := parseAmount(args[6])
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	claimType, err := types.StringToClaimType(args[7])
+	if err != nil {
+		return types.EthBridgeClaim{}, err
+	}
+
+	ethereumTxHashString := viper.GetString(types.FlagEthereumTxHash)
+	if !types.IsHexEthereumTxHash(ethereumTxHashString) {
+		return types.EthBridgeClaim{}, errors.Errorf("invalid [ethereum-tx-hash]: %s", ethereumTxHashString)
+	}
+	ethereumTxHash := types.NewEthereumTxHash(ethereumTxHashString)
+
+	ethereumBlockNumber := viper.GetUint64(types.FlagEthereumBlockNumber)
+	ethereumLogIndex := viper.GetUint64(types.FlagEthereumLogIndex)
+
+	return types.NewEthBridgeClaim(ethereumChainID, bridgeContract, nonce, symbol, tokenContract,
+		ethereumSender, cosmosReceiver, validator, amount, claimType,
+		ethereumTxHash, ethereumBlockNumber, ethereumLogIndex), nil
+}
+
 // GetCmdBurn is the CLI command for burning some of your eth and triggering an event
 //nolint:lll
 func GetCmdBurn(cdc *codec.Codec) *cobra.Command {
@@ -130,17 +279,10 @@ func GetCmdBurn(cdc *codec.Codec) *cobra.Command {
 			}
 			ethereumReceiver := types.NewEthereumAddress(args[1])
 
-			var digitCheck = regexp.MustCompile(`^[0-9]+$`)
-			if !digitCheck.MatchString(args[2]) {
-				return types.ErrInvalidAmount
-			}
-			amount, err := strconv.ParseInt(args[2], 10, 64)
+			amount, err := parseAmount(args[2])
 			if err != nil {
 				return err
 			}
-			if amount <= 0 {
-				return types.ErrInvalidAmount
-			}
 
 			symbol := args[3]
 
@@ -183,17 +325,10 @@ func GetCmdLock(cdc *codec.Codec) *cobra.Command {
 			}
 			ethereumReceiver := types.NewEthereumAddress(args[1])
 
-			var digitCheck = regexp.MustCompile(`^[0-9]+$`)
-			if !digitCheck.MatchString(args[2]) {
-				return types.ErrInvalidAmount
-			}
-			amount, err := strconv.ParseInt(args[2], 10, 64)
+			amount, err := parseAmount(args[2])
 			if err != nil {
 				return err
 			}
-			if amount <= 0 {
-				return types.ErrInvalidAmount
-			}
 
 			symbol := args[3]
 
@@ -206,3 +341,73 @@ func GetCmdLock(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdSubmitResolveProphecyProposal is the CLI command for submitting a governance proposal to resolve the
+// prophecy of an ethereum event
+func GetCmdSubmitResolveProphecyProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resolve-prophecy [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to fail a stuck prophecy or to execute the final claim of a wrong one",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to resolve the prophecy of an ethereum event along with an initial deposit.
+The prophecy fails if the proposal has no final claim, and its final claim is minted or unlocked otherwise.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal resolve-prophecy <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Execute stuck transfer",
+  "description": "Mint the tokens of the lock of nonce 12 that validators failed to agree on",
+  "ethereum_chain_id": 3,
+  "bridge_registry_contract_address": "0xC4cE93a5699c68241fc2fB503Fb0f21724A624BB",
+  "nonce": 12,
+  "ethereum_sender": "0x7B95B6EC7EbD73572298cEf32Bb54FA408207359",
+  "final_claim": {
+    "ethereum_chain_id": 3,
+    "cosmos_receiver": "cosmos1gn8409qq9hnrxde37kuxwx5hrxpfpv8426szuv",
+    "amount": "10",
+    "symbol": "eth",
+    "token_contract_address": "0x0000000000000000000000000000000000000000",
+    "claim_type": "lock",
+    "ethereum_tx_hash": "0x9e6ba5ba4e5d3ee1b1c6c2aaae4ae7bba6cf50fa5c17ec1e9caea5bbc81de1f2",
+    "ethereum_block_number": 12,
+    "ethereum_log_index": 1
+  },
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtypes.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			proposal, err := ParseResolveProphecyProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewResolveProphecyProposal(proposal.Title, proposal.Description,
+				proposal.EthereumChainID, proposal.BridgeContractAddress, proposal.Nonce, proposal.EthereumSender,
+				proposal.FinalClaim)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
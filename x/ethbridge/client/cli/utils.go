@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"io/ioutil"
+	"regexp"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+// ResolveProphecyProposalJSON defines a ResolveProphecyProposal with a deposit
+type ResolveProphecyProposalJSON struct {
+	Title                 string                    `json:"title" yaml:"title"`
+	Description           string                    `json:"description" yaml:"description"`
+	EthereumChainID       int                       `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
+	BridgeContractAddress types.EthereumAddress     `json:"bridge_registry_contract_address" yaml:"bridge_registry_contract_address"` //nolint:lll
+	Nonce                 int                       `json:"nonce" yaml:"nonce"`
+	EthereumSender        types.EthereumAddress     `json:"ethereum_sender" yaml:"ethereum_sender"`
+	FinalClaim            *types.OracleClaimContent `json:"final_claim,omitempty" yaml:"final_claim,omitempty"`
+	Deposit               sdk.Coins                 `json:"deposit" yaml:"deposit"`
+}
+
+// ParseResolveProphecyProposalJSON reads and parses a ResolveProphecyProposalJSON from a file.
+func ParseResolveProphecyProposalJSON(cdc *codec.Codec, proposalFile string) (ResolveProphecyProposalJSON, error) {
+	proposal := ResolveProphecyProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// parseAmount parses a positive decimal amount. Amounts that do not fit in an sdk.Int are rejected rather than
+// truncated.
+func parseAmount(amountString string) (sdk.Int, error) {
+	if !regexp.MustCompile(`^[0-9]+$`).MatchString(amountString) {
+		return sdk.Int{}, sdkerrors.Wrap(types.ErrInvalidAmount, amountString)
+	}
+	amount, ok := sdk.NewIntFromString(amountString)
+	if !ok {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidAmount, "%s overflows", amountString)
+	}
+	if !amount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrap(types.ErrInvalidAmount, amountString)
+	}
+	return amount, nil
+}
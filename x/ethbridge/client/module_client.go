@@ -21,6 +21,7 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 	ethBridgeQueryCmd.AddCommand(flags.GetCommands(
 		cli.GetCmdGetEthBridgeProphecy(storeKey, cdc),
+		cli.GetCmdGetEthBridgePropheciesByTxHash(storeKey, cdc),
 	)...)
 
 	return ethBridgeQueryCmd
@@ -35,6 +36,9 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 	ethBridgeTxCmd.AddCommand(flags.PostCommands(
 		cli.GetCmdCreateEthBridgeClaim(cdc),
+		cli.GetCmdCommitEthBridgeClaim(cdc),
+		cli.GetCmdRevealEthBridgeClaim(cdc),
+		cli.GetCmdRejectEthBridgeClaim(cdc),
 		cli.GetCmdBurn(cdc),
 		cli.GetCmdLock(cdc),
 	)...)
@@ -0,0 +1,13 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+
+	"github.com/sifchain/peggy/x/ethbridge/client/cli"
+	"github.com/sifchain/peggy/x/ethbridge/client/rest"
+)
+
+// ResolveProphecyProposalHandler is the governance proposal handler of the resolve prophecy proposals
+var ResolveProphecyProposalHandler = govclient.NewProposalHandler(
+	cli.GetCmdSubmitResolveProphecyProposal, rest.ResolveProphecyProposalRESTHandler,
+)
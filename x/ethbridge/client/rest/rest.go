@@ -9,6 +9,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 
 	"github.com/gorilla/mux"
 
@@ -24,6 +26,7 @@ const (
 	restSymbol          = "symbol"
 	restTokenContract   = "tokenContract"
 	restEthereumSender  = "ethereumSender"
+	restEthereumTxHash  = "ethereumTxHash"
 )
 
 type createEthClaimReq struct {
@@ -36,8 +39,11 @@ type createEthClaimReq struct {
 	EthereumSender        string       `json:"ethereum_sender"`
 	CosmosReceiver        string       `json:"cosmos_receiver"`
 	Validator             string       `json:"validator"`
-	Amount                int64        `json:"amount"`
+	Amount                sdk.Int      `json:"amount"`
 	ClaimType             string       `json:"claim_type"`
+	EthereumTxHash        string       `json:"ethereum_tx_hash"`
+	EthereumBlockNumber   uint64       `json:"ethereum_block_number"`
+	EthereumLogIndex      uint64       `json:"ethereum_log_index"`
 }
 
 type burnOrLockEthReq struct {
@@ -46,10 +52,23 @@ type burnOrLockEthReq struct {
 	TokenContract    string       `json:"token_contract_address"`
 	CosmosSender     string       `json:"cosmos_sender"`
 	EthereumReceiver string       `json:"ethereum_receiver"`
-	Amount           int64        `json:"amount"`
+	Amount           sdk.Int      `json:"amount"`
 	Symbol           string       `json:"symbol"`
 }
 
+type resolveProphecyProposalReq struct {
+	BaseReq               rest.BaseReq              `json:"base_req"`
+	Title                 string                    `json:"title"`
+	Description           string                    `json:"description"`
+	EthereumChainID       int                       `json:"ethereum_chain_id"`
+	BridgeContractAddress string                    `json:"bridge_registry_contract_address"`
+	Nonce                 int                       `json:"nonce"`
+	EthereumSender        string                    `json:"ethereum_sender"`
+	FinalClaim            *types.OracleClaimContent `json:"final_claim"`
+	Proposer              sdk.AccAddress            `json:"proposer"`
+	Deposit               sdk.Coins                 `json:"deposit"`
+}
+
 // RegisterRESTRoutes - Central function to define routes that get registered by the main application
 func RegisterRESTRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
 	r.HandleFunc(fmt.Sprintf("/%s/prophecies", storeName), createClaimHandler(cliCtx)).Methods("POST")
@@ -57,6 +76,8 @@ func RegisterRESTRoutes(cliCtx context.CLIContext, r *mux.Router, storeName stri
 		fmt.Sprintf("/%s/prophecies/{%s}/{%s}/{%s}/{%s}/{%s}/{%s}",
 			storeName, restEthereumChainID, restBridgeContract, restNonce, restSymbol, restTokenContract, restEthereumSender),
 		getProphecyHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/tx_hashes/{%s}/prophecies", storeName, restEthereumTxHash),
+		getPropheciesByTxHashHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/burn", storeName), burnOrLockHandler(cliCtx, "burn")).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/lock", storeName), burnOrLockHandler(cliCtx, "lock")).Methods("POST")
 }
@@ -98,10 +119,17 @@ func createClaimHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		if !types.IsHexEthereumTxHash(req.EthereumTxHash) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, types.ErrInvalidEthTxHash.Error())
+			return
+		}
+		ethereumTxHash := types.NewEthereumTxHash(req.EthereumTxHash)
+
 		// create the message
 		ethBridgeClaim := types.NewEthBridgeClaim(
 			req.EthereumChainID, bridgeContractAddress, req.Nonce, req.Symbol,
-			tokenContractAddress, ethereumSender, cosmosReceiver, validator, req.Amount, claimType)
+			tokenContractAddress, ethereumSender, cosmosReceiver, validator, req.Amount, claimType,
+			ethereumTxHash, req.EthereumBlockNumber, req.EthereumLogIndex)
 		msg := types.NewMsgCreateEthBridgeClaim(ethBridgeClaim)
 		err = msg.ValidateBasic()
 		if err != nil {
@@ -162,6 +190,32 @@ func getProphecyHandler(cliCtx context.CLIContext, storeName string) http.Handle
 	}
 }
 
+func getPropheciesByTxHashHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ethereumTxHash := mux.Vars(r)[restEthereumTxHash]
+		if !types.IsHexEthereumTxHash(ethereumTxHash) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, types.ErrInvalidEthTxHash.Error())
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(
+			types.NewQueryEthPropheciesByTxHashParams(types.NewEthereumTxHash(ethereumTxHash)))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", storeName, types.QueryEthPropheciesByTxHash)
+		res, _, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func burnOrLockHandler(cliCtx context.CLIContext, lockOrBurn string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req burnOrLockEthReq
@@ -207,3 +261,39 @@ func burnOrLockHandler(cliCtx context.CLIContext, lockOrBurn string) http.Handle
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
+
+// ResolveProphecyProposalRESTHandler returns a ProposalRESTHandler that exposes the resolve prophecy REST handler
+// with a given sub-route.
+func ResolveProphecyProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "resolve_prophecy",
+		Handler:  resolveProphecyProposalHandler(cliCtx),
+	}
+}
+
+func resolveProphecyProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req resolveProphecyProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewResolveProphecyProposal(req.Title, req.Description, req.EthereumChainID,
+			types.NewEthereumAddress(req.BridgeContractAddress), req.Nonce, types.NewEthereumAddress(req.EthereumSender),
+			req.FinalClaim)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
@@ -0,0 +1,34 @@
+package ethbridge
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+// InitGenesis initializes the ethbridge module's state from a given genesis state
+func InitGenesis(ctx sdk.Context, keeper Keeper, supplyKeeper types.SupplyKeeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+
+	// The bridge account holds the coins locked on this chain. It is imported with its balance by the auth
+	// module, so it must only be created when it does not exist yet rather than overwritten by an empty one.
+	if moduleAccount := supplyKeeper.GetModuleAccount(ctx, ModuleName); moduleAccount == nil {
+		panic(fmt.Sprintf("%s module account has not been set", ModuleName))
+	}
+
+	// Genesis states exported before the bridge tracked the coins it locks and mints have none, they are then
+	// tracked from the imported balances like on chains upgraded in place
+	if data.LockedCoins.Empty() && data.PeggedCoins.Empty() {
+		keeper.SetBridgedCoinsFromBalances(ctx)
+		return
+	}
+	keeper.SetLockedCoins(ctx, data.LockedCoins)
+	keeper.SetPeggedCoins(ctx, data.PeggedCoins)
+}
+
+// ExportGenesis returns the ethbridge module's genesis state for the current context
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return NewGenesisState(keeper.GetParams(ctx), keeper.GetLockedCoins(ctx), keeper.GetPeggedCoins(ctx))
+}
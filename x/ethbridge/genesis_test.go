@@ -0,0 +1,123 @@
+package ethbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
+	keeperLib "github.com/sifchain/peggy/x/oracle/keeper"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	testBridgeContractAddress := types.NewEthereumAddress(types.TestBridgeContractAddress)
+	testTokenContractAddress := types.NewEthereumAddress(types.TestTokenContractAddress)
+	params := NewParams(
+		[]int{types.TestEthereumChainID},
+		[]EthereumAddress{testBridgeContractAddress},
+		"wrapped",
+		[]RegisteredToken{NewRegisteredToken(types.TestEthereumChainID, testTokenContractAddress, types.TestCoinsSymbol,
+			"wrappedeth", true)},
+	)
+	ctx, _, _, supplyKeeper, accountKeeper, bridgeKeeper, _, _ := CreateTestHandlerWithParams(
+		t, 0.7, []int64{3, 7}, params)
+
+	genesis := ExportGenesis(ctx, bridgeKeeper)
+	require.NoError(t, ValidateGenesis(genesis))
+
+	bz := ModuleCdc.MustMarshalJSON(genesis)
+	var importedGenesis GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &importedGenesis)
+	require.Equal(t, genesis, importedGenesis)
+
+	// Escrowed coins of the bridge account survive the import
+	lockedCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	moduleAccount := supplyKeeper.GetModuleAccount(ctx, ModuleName)
+	require.NoError(t, moduleAccount.SetCoins(lockedCoins))
+	accountKeeper.SetAccount(ctx, moduleAccount)
+
+	InitGenesis(ctx, bridgeKeeper, supplyKeeper, importedGenesis)
+	require.Equal(t, params, bridgeKeeper.GetParams(ctx))
+	require.Equal(t, lockedCoins, supplyKeeper.GetModuleAccount(ctx, ModuleName).GetCoins())
+
+	// Genesis states without the coins tracked by the bridge track them from the imported balances
+	require.Equal(t, lockedCoins, bridgeKeeper.GetLockedCoins(ctx))
+	require.True(t, bridgeKeeper.GetPeggedCoins(ctx).Empty())
+
+	// and the tracked coins survive the export and import
+	trackedLockedCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 6))
+	peggedCoins := sdk.NewCoins(sdk.NewInt64Coin("wrappedeth", 3))
+	InitGenesis(ctx, bridgeKeeper, supplyKeeper, NewGenesisState(params, trackedLockedCoins, peggedCoins))
+	genesis = ExportGenesis(ctx, bridgeKeeper)
+	require.Equal(t, trackedLockedCoins, genesis.LockedCoins)
+	require.Equal(t, peggedCoins, genesis.PeggedCoins)
+}
+
+func TestValidateGenesis(t *testing.T) {
+	testBridgeContractAddress := types.NewEthereumAddress(types.TestBridgeContractAddress)
+	testTokenContractAddress := types.NewEthereumAddress(types.TestTokenContractAddress)
+	token := types.CreateTestRegisteredToken()
+	altToken := NewRegisteredToken(types.TestEthereumChainID, testBridgeContractAddress, "usdt", token.Denom, true)
+
+	testCases := []struct {
+		name     string
+		params   Params
+		expError bool
+	}{
+		{"default params", DefaultParams(), false},
+		{"valid params", NewParams([]int{types.TestEthereumChainID}, []EthereumAddress{testBridgeContractAddress},
+			DefaultPeggedCoinPrefix, []RegisteredToken{token}), false},
+		{"invalid chain id", NewParams([]int{0}, []EthereumAddress{}, DefaultPeggedCoinPrefix, []RegisteredToken{}), true},
+		{"duplicate chain id", NewParams([]int{types.TestEthereumChainID, types.TestEthereumChainID},
+			[]EthereumAddress{}, DefaultPeggedCoinPrefix, []RegisteredToken{}), true},
+		{"null bridge contract", NewParams([]int{}, []EthereumAddress{{}}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{}), true},
+		{"duplicate bridge contract", NewParams([]int{},
+			[]EthereumAddress{testBridgeContractAddress, testBridgeContractAddress}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{}), true},
+		{"empty pegged coin prefix", NewParams([]int{}, []EthereumAddress{}, "", []RegisteredToken{}), true},
+		{"invalid pegged coin prefix", NewParams([]int{}, []EthereumAddress{}, "Peggy", []RegisteredToken{}), true},
+		{"duplicate registered token", NewParams([]int{}, []EthereumAddress{}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{token, token}), true},
+		{"registered token without symbol", NewParams([]int{}, []EthereumAddress{}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{NewRegisteredToken(types.TestEthereumChainID, testTokenContractAddress, "",
+				token.Denom, true)}), true},
+		{"registered token with invalid denom", NewParams([]int{}, []EthereumAddress{}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{NewRegisteredToken(types.TestEthereumChainID, testTokenContractAddress,
+				types.TestCoinsSymbol, "Peggy ETH", true)}), true},
+		{"denom registered for several tokens", NewParams([]int{}, []EthereumAddress{}, DefaultPeggedCoinPrefix,
+			[]RegisteredToken{token, altToken}), true},
+		{"registered token on unaccepted chain", NewParams([]int{types.TestEthereumChainID + 1}, []EthereumAddress{},
+			DefaultPeggedCoinPrefix, []RegisteredToken{token}), true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGenesis(NewGenesisState(tc.params, sdk.Coins{}, sdk.Coins{}))
+			if tc.expError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	invalidCoins := sdk.Coins{sdk.NewInt64Coin("stake", 0)}
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), invalidCoins, sdk.Coins{})))
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), sdk.Coins{}, invalidCoins)))
+}
+
+func TestGetParamsNotSet(t *testing.T) {
+	input := oracle.CreateTestInput(t, 0.7, []int64{3, 7}, ModuleName)
+	oracleKeeper := input.OracleKeeper
+	bridgeKeeper := NewKeeper(keeperLib.MakeTestCodec(), input.ModuleStoreKey,
+		input.ParamsKeeper.Subspace(DefaultParamspace), input.SupplyKeeper, &oracleKeeper)
+
+	// chains upgraded in place from a version without the ethbridge parameters read their default value
+	require.Equal(t, types.DefaultParams(), bridgeKeeper.GetParams(input.Ctx))
+	require.Equal(t, DefaultPeggedCoinPrefix, bridgeKeeper.GetPeggedCoinPrefix(input.Ctx))
+}
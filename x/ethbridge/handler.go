@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 // NewHandler returns a handler for "ethbridge" type messages.
@@ -22,6 +23,12 @@ func NewHandler(
 		switch msg := msg.(type) {
 		case MsgCreateEthBridgeClaim:
 			return handleMsgCreateEthBridgeClaim(ctx, cdc, bridgeKeeper, msg)
+		case MsgCommitEthBridgeClaim:
+			return handleMsgCommitEthBridgeClaim(ctx, cdc, bridgeKeeper, msg)
+		case MsgRevealEthBridgeClaim:
+			return handleMsgRevealEthBridgeClaim(ctx, cdc, bridgeKeeper, msg)
+		case MsgRejectEthBridgeClaim:
+			return handleMsgRejectEthBridgeClaim(ctx, cdc, bridgeKeeper, msg)
 		case MsgBurn:
 			return handleMsgBurn(ctx, cdc, accountKeeper, bridgeKeeper, msg)
 		case MsgLock:
@@ -33,18 +40,41 @@ func NewHandler(
 	}
 }
 
+// NewProposalHandler returns a handler for "ethbridge" type governance proposals.
+func NewProposalHandler(bridgeKeeper Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case ResolveProphecyProposal:
+			return HandleResolveProphecyProposal(ctx, bridgeKeeper, c)
+		default:
+			errMsg := fmt.Sprintf("unrecognized ethbridge proposal content type: %T", c)
+			return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
+	}
+}
+
 // Handle a message to create a bridge claim
 func handleMsgCreateEthBridgeClaim(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgCreateEthBridgeClaim,
 ) (*sdk.Result, error) {
+	// the claim of a prophecy that succeeds is processed by the oracle hooks
 	status, err := bridgeKeeper.ProcessClaim(ctx, types.EthBridgeClaim(msg))
 	if err != nil {
 		return nil, err
 	}
-	if status.Text == oracle.SuccessStatusText {
-		if err = bridgeKeeper.ProcessSuccessfulClaim(ctx, status.FinalClaim); err != nil {
-			return nil, err
-		}
+
+	ctx.EventManager().EmitEvents(newClaimEvents(types.EthBridgeClaim(msg), status))
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle a message to commit to a bridge claim
+func handleMsgCommitEthBridgeClaim(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgCommitEthBridgeClaim,
+) (*sdk.Result, error) {
+	status, err := bridgeKeeper.ProcessCommit(ctx, msg)
+	if err != nil {
+		return nil, err
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -54,13 +84,57 @@ func handleMsgCreateEthBridgeClaim(
 			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddress.String()),
 		),
 		sdk.NewEvent(
-			types.EventTypeCreateClaim,
+			types.EventTypeCommitClaim,
+			sdk.NewAttribute(types.AttributeKeyEthereumChainID, strconv.Itoa(msg.EthereumChainID)),
 			sdk.NewAttribute(types.AttributeKeyEthereumSender, msg.EthereumSender.String()),
-			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, msg.CosmosReceiver.String()),
-			sdk.NewAttribute(types.AttributeKeyAmount, strconv.FormatInt(msg.Amount, 10)),
-			sdk.NewAttribute(types.AttributeKeySymbol, msg.Symbol),
-			sdk.NewAttribute(types.AttributeKeyTokenContract, msg.TokenContractAddress.String()),
-			sdk.NewAttribute(types.AttributeKeyClaimType, msg.ClaimType.String()),
+			sdk.NewAttribute(types.AttributeKeyProphecyID,
+				types.ProphecyID(msg.EthereumChainID, msg.BridgeContractAddress, msg.Nonce, msg.EthereumSender)),
+		),
+		sdk.NewEvent(
+			types.EventTypeProphecyStatus,
+			sdk.NewAttribute(types.AttributeKeyStatus, status.Text.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle a message to reveal a committed bridge claim
+func handleMsgRevealEthBridgeClaim(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRevealEthBridgeClaim,
+) (*sdk.Result, error) {
+	// the claim of a prophecy that succeeds is processed by the oracle hooks
+	status, err := bridgeKeeper.ProcessReveal(ctx, msg.EthBridgeClaim, msg.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(newClaimEvents(msg.EthBridgeClaim, status))
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle a message to reject the prophecy of a bridge claim
+func handleMsgRejectEthBridgeClaim(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRejectEthBridgeClaim,
+) (*sdk.Result, error) {
+	status, err := bridgeKeeper.ProcessRejection(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddress.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeRejectClaim,
+			sdk.NewAttribute(types.AttributeKeyEthereumChainID, strconv.Itoa(msg.EthereumChainID)),
+			sdk.NewAttribute(types.AttributeKeyEthereumSender, msg.EthereumSender.String()),
+			sdk.NewAttribute(types.AttributeKeyProphecyID,
+				types.ProphecyID(msg.EthereumChainID, msg.BridgeContractAddress, msg.Nonce, msg.EthereumSender)),
 		),
 		sdk.NewEvent(
 			types.EventTypeProphecyStatus,
@@ -71,6 +145,31 @@ func handleMsgCreateEthBridgeClaim(
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+// newClaimEvents returns the events of a bridge claim made by a validator
+func newClaimEvents(claim types.EthBridgeClaim, status oracle.Status) sdk.Events {
+	return sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, claim.ValidatorAddress.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeCreateClaim,
+			sdk.NewAttribute(types.AttributeKeyEthereumSender, claim.EthereumSender.String()),
+			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, claim.CosmosReceiver.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, claim.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySymbol, claim.Symbol),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, claim.TokenContractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyClaimType, claim.ClaimType.String()),
+			sdk.NewAttribute(types.AttributeKeyEthereumTxHash, claim.EthereumTxHash.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeProphecyStatus,
+			sdk.NewAttribute(types.AttributeKeyStatus, status.Text.String()),
+		),
+	}
+}
+
 func handleMsgBurn(
 	ctx sdk.Context, cdc *codec.Codec, accountKeeper types.AccountKeeper,
 	bridgeKeeper Keeper, msg MsgBurn,
@@ -81,7 +180,11 @@ func handleMsgBurn(
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.CosmosSender.String())
 	}
 
-	coins := sdk.NewCoins(sdk.NewInt64Coin(msg.Symbol, msg.Amount))
+	if err := bridgeKeeper.ValidateEthereumChainID(ctx, msg.EthereumChainID); err != nil {
+		return nil, err
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(msg.Symbol, msg.Amount))
 	if err := bridgeKeeper.ProcessBurn(ctx, msg.CosmosSender, coins); err != nil {
 		return nil, err
 	}
@@ -97,7 +200,7 @@ func handleMsgBurn(
 			sdk.NewAttribute(types.AttributeKeyEthereumChainID, strconv.Itoa(msg.EthereumChainID)),
 			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.CosmosSender.String()),
 			sdk.NewAttribute(types.AttributeKeyEthereumReceiver, msg.EthereumReceiver.String()),
-			sdk.NewAttribute(types.AttributeKeyAmount, strconv.FormatInt(msg.Amount, 10)),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySymbol, msg.Symbol),
 			sdk.NewAttribute(types.AttributeKeyCoins, coins.String()),
 		),
@@ -117,7 +220,11 @@ func handleMsgLock(
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.CosmosSender.String())
 	}
 
-	coins := sdk.NewCoins(sdk.NewInt64Coin(msg.Symbol, msg.Amount))
+	if err := bridgeKeeper.ValidateEthereumChainID(ctx, msg.EthereumChainID); err != nil {
+		return nil, err
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(msg.Symbol, msg.Amount))
 	if err := bridgeKeeper.ProcessLock(ctx, msg.CosmosSender, coins); err != nil {
 		return nil, err
 	}
@@ -133,7 +240,7 @@ func handleMsgLock(
 			sdk.NewAttribute(types.AttributeKeyEthereumChainID, strconv.Itoa(msg.EthereumChainID)),
 			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.CosmosSender.String()),
 			sdk.NewAttribute(types.AttributeKeyEthereumReceiver, msg.EthereumReceiver.String()),
-			sdk.NewAttribute(types.AttributeKeyAmount, strconv.FormatInt(msg.Amount, 10)),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySymbol, msg.Symbol),
 			sdk.NewAttribute(types.AttributeKeyCoins, coins.String()),
 		),
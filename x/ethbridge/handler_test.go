@@ -1,7 +1,9 @@
 package ethbridge
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"testing"
@@ -60,6 +62,8 @@ func TestBasicMsgs(t *testing.T) {
 				require.Equal(t, value, oracle.StatusTextToString[oracle.PendingStatusText])
 			case "claim_type":
 				require.Equal(t, value, types.ClaimTypeToString[types.LockText])
+			case "ethereum_tx_hash":
+				require.Equal(t, value, types.TestEthereumTxHash)
 			default:
 				require.Fail(t, fmt.Sprintf("unrecognized event %s", key))
 			}
@@ -142,6 +146,208 @@ func TestMintSuccess(t *testing.T) {
 	require.True(t, receiverCoins.IsEqual(expectedCoins))
 }
 
+func TestMintLargeAmount(t *testing.T) {
+	ctx, _, bankKeeper, _, _, validatorAddresses, handler := CreateTestHandler(t, 0.5, []int64{5})
+
+	// 100 tokens of 18 decimals do not fit in an int64
+	amount, ok := sdk.NewIntFromString("100000000000000000000")
+	require.True(t, ok)
+	ethMsg := types.CreateTestEthMsg(t, validatorAddresses[0], types.LockText)
+	ethMsg.Amount = amount
+	require.NoError(t, ethMsg.ValidateBasic())
+	_, err := handler(ctx, ethMsg)
+	require.NoError(t, err)
+
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	require.Equal(t, amount, bankKeeper.GetCoins(ctx, receiverAddress).AmountOf(types.TestCoinsLockedSymbol))
+
+	// claims and messages without an amount are rejected
+	ethMsg.Amount = sdk.Int{}
+	require.True(t, types.ErrInvalidAmount.Is(ethMsg.ValidateBasic()))
+	burnMsg := types.CreateTestBurnMsg(t, types.TestAddress, types.NewEthereumAddress(types.AltTestEthereumAddress), 1,
+		types.TestCoinsLockedSymbol)
+	burnMsg.Amount = sdk.Int{}
+	require.True(t, types.ErrInvalidAmount.Is(burnMsg.ValidateBasic()))
+
+	// amounts hold at most 255 bits, larger amounts fail to decode
+	maxAmount := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	burnMsg.Amount = sdk.NewIntFromBigInt(maxAmount)
+	var decodedMsg MsgBurn
+	require.NoError(t, ModuleCdc.UnmarshalJSON(ModuleCdc.MustMarshalJSON(burnMsg), &decodedMsg))
+	require.Equal(t, burnMsg.Amount, decodedMsg.Amount)
+	bz := bytes.Replace(ModuleCdc.MustMarshalJSON(burnMsg), []byte(maxAmount.String()),
+		[]byte(new(big.Int).Add(maxAmount, big.NewInt(1)).String()), 1)
+	require.Error(t, ModuleCdc.UnmarshalJSON(bz, &decodedMsg))
+
+	// and so are messages whose symbol is not a valid denom, which coins could not be made of
+	burnMsg.Amount = sdk.OneInt()
+	burnMsg.Symbol = "Peggy ETH"
+	require.True(t, types.ErrInvalidSymbol.Is(burnMsg.ValidateBasic()))
+	lockMsg := types.NewMsgLock(types.TestEthereumChainID, receiverAddress,
+		types.NewEthereumAddress(types.AltTestEthereumAddress), sdk.OneInt(), "Stake")
+	require.True(t, types.ErrInvalidSymbol.Is(lockMsg.ValidateBasic()))
+}
+
+func TestCommitRevealMint(t *testing.T) {
+	ctx, oracleKeeper, bankKeeper, _, _, validatorAddresses, handler := CreateTestHandler(t, 0.7, []int64{3, 7})
+	oracleParams := oracleKeeper.GetParams(ctx)
+	oracleParams.CommitWindowBlocks = 2
+	oracleKeeper.SetParams(ctx, oracleParams)
+
+	valAddressVal1Pow3 := validatorAddresses[0]
+	valAddressVal2Pow7 := validatorAddresses[1]
+
+	// claims must be committed before they are revealed
+	res, err := handler(ctx, types.CreateTestEthMsg(t, valAddressVal1Pow3, types.LockText))
+	require.Error(t, err)
+	require.Nil(t, res)
+	require.True(t, oracle.ErrCommitRequired.Is(err))
+
+	var reveals []MsgRevealEthBridgeClaim
+	for _, valAddress := range []sdk.ValAddress{valAddressVal1Pow3, valAddressVal2Pow7} {
+		claim := types.EthBridgeClaim(types.CreateTestEthMsg(t, valAddress, types.LockText))
+		salt := valAddress.String()
+		commitment, err := types.EthBridgeClaimCommitment(types.ModuleCdc, claim, salt)
+		require.NoError(t, err)
+
+		commitMsg := types.NewMsgCommitEthBridgeClaim(claim.EthereumChainID, claim.BridgeContractAddress, claim.Nonce,
+			claim.EthereumSender, valAddress, commitment)
+		require.NoError(t, commitMsg.ValidateBasic())
+		res, err = handler(ctx, commitMsg)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+
+		reveals = append(reveals, types.NewMsgRevealEthBridgeClaim(claim, salt))
+	}
+
+	// reveals are rejected until the commit window closes
+	_, err = handler(ctx, reveals[0])
+	require.True(t, oracle.ErrRevealTooEarly.Is(err))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 2)
+	wrongSalt := reveals[0]
+	wrongSalt.Salt = "wrong"
+	_, err = handler(ctx, wrongSalt)
+	require.True(t, oracle.ErrInvalidReveal.Is(err))
+
+	for _, reveal := range reveals {
+		require.NoError(t, reveal.ValidateBasic())
+		res, err = handler(ctx, reveal)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+	}
+
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	receiverCoins := bankKeeper.GetCoins(ctx, receiverAddress)
+	expectedCoins := sdk.Coins{sdk.NewInt64Coin(types.TestCoinsLockedSymbol, types.TestCoinsAmount)}
+	require.True(t, receiverCoins.IsEqual(expectedCoins))
+}
+
+func TestRejectClaim(t *testing.T) {
+	ctx, _, bankKeeper, _, _, validatorAddresses, handler := CreateTestHandler(t, 0.6, []int64{2, 4, 4})
+
+	valAddressVal1Pow2 := validatorAddresses[0]
+	valAddressVal2Pow4 := validatorAddresses[1]
+	valAddressVal3Pow4 := validatorAddresses[2]
+
+	createMsg := types.CreateTestEthMsg(t, valAddressVal1Pow2, types.LockText)
+	_, err := handler(ctx, createMsg)
+	require.NoError(t, err)
+
+	// the prophecy could still succeed, but more than a third of the power rejecting it fails it without minting
+	rejectMsg := types.NewMsgRejectEthBridgeClaim(createMsg.EthereumChainID, createMsg.BridgeContractAddress,
+		createMsg.Nonce, createMsg.EthereumSender, valAddressVal2Pow4)
+	require.NoError(t, rejectMsg.ValidateBasic())
+	res, err := handler(ctx, rejectMsg)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	for _, event := range res.Events {
+		for _, attribute := range event.Attributes {
+			if string(attribute.Key) == statusString {
+				require.Equal(t, oracle.StatusTextToString[oracle.FailedStatusText], string(attribute.Value))
+			}
+		}
+	}
+
+	_, err = handler(ctx, types.CreateTestEthMsg(t, valAddressVal3Pow4, types.LockText))
+	require.True(t, oracle.ErrProphecyFinalized.Is(err))
+
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, receiverAddress).IsZero())
+}
+
+func TestResolveProphecyProposal(t *testing.T) {
+	ctx, _, bankKeeper, _, _, bridgeKeeper, validatorAddresses, handler :=
+		CreateTestHandlerWithParams(t, 0.6, []int64{2, 4, 4}, types.CreateTestParams())
+	proposalHandler := NewProposalHandler(bridgeKeeper)
+
+	createMsg := types.CreateTestEthMsg(t, validatorAddresses[0], types.LockText)
+	_, err := handler(ctx, createMsg)
+	require.NoError(t, err)
+	otherCreateMsg := createMsg
+	otherCreateMsg.Nonce++
+	_, err = handler(ctx, otherCreateMsg)
+	require.NoError(t, err)
+
+	finalClaim := types.NewOracleClaimContent(createMsg.EthereumChainID, createMsg.CosmosReceiver, createMsg.Amount,
+		createMsg.Symbol, createMsg.TokenContractAddress, createMsg.ClaimType, createMsg.EthereumTxHash,
+		createMsg.EthereumBlockNumber, createMsg.EthereumLogIndex)
+	proposal := types.NewResolveProphecyProposal("title", "description", createMsg.EthereumChainID,
+		createMsg.BridgeContractAddress, createMsg.Nonce, createMsg.EthereumSender, nil)
+	require.NoError(t, proposal.ValidateBasic())
+
+	// a stuck prophecy is failed without minting
+	require.NoError(t, proposalHandler(ctx, proposal))
+	_, err = handler(ctx, types.CreateTestEthMsg(t, validatorAddresses[1], types.LockText))
+	require.True(t, oracle.ErrProphecyFinalized.Is(err))
+	require.True(t, oracle.ErrProphecyFinalized.Is(proposalHandler(ctx, proposal)))
+
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, receiverAddress).IsZero())
+
+	// and its final claim can then be executed, which mints its coins
+	proposal.FinalClaim = &finalClaim
+	require.NoError(t, proposal.ValidateBasic())
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, proposalHandler(ctx, proposal))
+	expectedCoins := sdk.Coins{sdk.NewInt64Coin(types.TestCoinsLockedSymbol, types.TestCoinsAmount)}
+	require.True(t, bankKeeper.GetCoins(ctx, receiverAddress).IsEqual(expectedCoins))
+	require.True(t, oracle.ErrProphecyFinalized.Is(proposalHandler(ctx, proposal)))
+
+	var eventTypes []string
+	for _, event := range ctx.EventManager().Events() {
+		eventTypes = append(eventTypes, event.Type)
+	}
+	require.Contains(t, eventTypes, oracle.EventTypeProphecyResolved)
+	require.Contains(t, eventTypes, types.EventTypeResolveClaim)
+
+	// final claims of prophecies that do not exist or with an unacceptable content are not executed
+	proposal.Nonce += 2
+	require.True(t, oracle.ErrProphecyNotFound.Is(proposalHandler(ctx, proposal)))
+	proposal.Nonce = otherCreateMsg.Nonce
+	invalidClaim := finalClaim
+	invalidClaim.Amount = sdk.ZeroInt()
+	proposal.FinalClaim = &invalidClaim
+	require.Error(t, proposal.ValidateBasic())
+	require.True(t, oracle.ErrInvalidClaim.Is(proposalHandler(ctx, proposal)))
+
+	// final claims must be about a registered token of the ethereum chain of the proposal
+	invalidClaim = finalClaim
+	invalidClaim.EthereumChainID++
+	proposal.FinalClaim = &invalidClaim
+	require.True(t, types.ErrInvalidEthereumChainID.Is(proposal.ValidateBasic()))
+	invalidClaim = finalClaim
+	invalidClaim.TokenContractAddress = types.NewEthereumAddress(types.AltTestEthereumAddress)
+	invalidClaim.Symbol = "usdt"
+	proposal.FinalClaim = &invalidClaim
+	require.NoError(t, proposal.ValidateBasic())
+	require.True(t, types.ErrUnregisteredToken.Is(proposalHandler(ctx, proposal)))
+}
+
 func TestNoMintFail(t *testing.T) {
 	//Setup
 	ctx, _, bankKeeper, _, _, validatorAddresses, handler := CreateTestHandler(t, 0.71, []int64{3, 4, 3})
@@ -210,8 +416,84 @@ func TestNoMintFail(t *testing.T) {
 	require.True(t, receiver1Coins.IsZero())
 }
 
+func TestLockAndReturnConservesSupply(t *testing.T) {
+	stakeContractAddress := types.NewEthereumAddress(types.TestEthereumAddress)
+	params := types.CreateTestParams()
+	params.TokenRegistry = append(params.TokenRegistry,
+		types.NewRegisteredToken(types.TestEthereumChainID, stakeContractAddress, "stake", "stake", true))
+	ctx, _, bankKeeper, supplyKeeper, _, _, validatorAddresses, handler :=
+		CreateTestHandlerWithParams(t, 0.5, []int64{5}, params)
+	valAddress := validatorAddresses[0]
+
+	senderAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	senderCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	require.NoError(t, supplyKeeper.MintCoins(ctx, ModuleName, senderCoins))
+	require.NoError(t, supplyKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, senderAddress, senderCoins))
+	supply := supplyKeeper.GetSupply(ctx).GetTotal().AmountOf("stake")
+	escrowOf := func(ctx sdk.Context) sdk.Int {
+		return supplyKeeper.GetModuleAccount(ctx, ModuleName).GetCoins().AmountOf("stake")
+	}
+
+	// Locked coins leave for ethereum through the escrow
+	lockMsg := types.NewMsgLock(types.TestEthereumChainID, senderAddress,
+		types.NewEthereumAddress(types.AltTestEthereumAddress), sdk.NewInt(10), "stake")
+	_, err = handler(ctx, lockMsg)
+	require.NoError(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, senderAddress).AmountOf("stake").IsZero())
+	require.Equal(t, sdk.NewInt(10), escrowOf(ctx))
+	require.Equal(t, supply, supplyKeeper.GetSupply(ctx).GetTotal().AmountOf("stake"))
+
+	// Burn claims return them from the escrow without minting
+	burnClaim := types.CreateTestEthMsg(t, valAddress, types.BurnText)
+	burnClaim.TokenContractAddress = stakeContractAddress
+	burnClaim.Symbol = "stake"
+	burnClaim.Amount = sdk.NewInt(4)
+	_, err = handler(ctx, burnClaim)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(4), bankKeeper.GetCoins(ctx, senderAddress).AmountOf("stake"))
+	require.Equal(t, sdk.NewInt(6), escrowOf(ctx))
+	require.Equal(t, supply, supplyKeeper.GetSupply(ctx).GetTotal().AmountOf("stake"))
+
+	// Burn claims returning more than the escrow holds fail
+	burnClaim.Nonce++
+	burnClaim.Amount = sdk.NewInt(7)
+	cacheCtx, _ := ctx.CacheContext()
+	_, err = handler(cacheCtx, burnClaim)
+	require.True(t, types.ErrInsufficientEscrow.Is(err))
+
+	burnClaim.Nonce++
+	burnClaim.Amount = sdk.NewInt(6)
+	_, err = handler(ctx, burnClaim)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(10), bankKeeper.GetCoins(ctx, senderAddress).AmountOf("stake"))
+	require.True(t, escrowOf(ctx).IsZero())
+	require.Equal(t, supply, supplyKeeper.GetSupply(ctx).GetTotal().AmountOf("stake"))
+}
+
 func TestBurnEthFail(t *testing.T) {
+	ctx, _, bankKeeper, supplyKeeper, _, bridgeKeeper, _, handler := CreateTestHandlerWithParams(
+		t, 0.5, []int64{5}, types.CreateTestParams())
 
+	// Pegged coins the bridge did not mint, such as balances of a genesis state that does not track them, cannot
+	// be burned
+	senderAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	peggedCoins := sdk.NewCoins(sdk.NewInt64Coin(types.TestCoinsLockedSymbol, 5))
+	require.NoError(t, supplyKeeper.MintCoins(ctx, ModuleName, peggedCoins))
+	require.NoError(t, supplyKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, senderAddress, peggedCoins))
+	bridgeKeeper.SetPeggedCoins(ctx, sdk.NewCoins(sdk.NewInt64Coin(types.TestCoinsLockedSymbol, 4)))
+
+	burnMsg := types.CreateTestBurnMsg(t, types.TestAddress, types.NewEthereumAddress(types.AltTestEthereumAddress), 5,
+		types.TestCoinsLockedSymbol)
+	_, err = handler(ctx, burnMsg)
+	require.True(t, types.ErrInsufficientPeggedCoins.Is(err))
+	require.Equal(t, peggedCoins, bankKeeper.GetCoins(ctx, senderAddress))
+
+	burnMsg.Amount = sdk.NewInt(4)
+	_, err = handler(ctx, burnMsg)
+	require.NoError(t, err)
+	require.True(t, bridgeKeeper.GetPeggedCoins(ctx).Empty())
 }
 
 func TestBurnEthSuccess(t *testing.T) {
@@ -223,8 +505,8 @@ func TestBurnEthSuccess(t *testing.T) {
 
 	// Initial message to mint some eth
 	coinsToMintAmount := int64(7)
-	coinsToMintSymbol := "ether"
-	coinsToMintSymbolLocked := fmt.Sprintf("%v%v", types.PeggedCoinPrefix, coinsToMintSymbol)
+	coinsToMintSymbol := types.TestCoinsSymbol
+	coinsToMintSymbolLocked := fmt.Sprintf("%v%v", types.DefaultPeggedCoinPrefix, coinsToMintSymbol)
 
 	testTokenContractAddress := types.NewEthereumAddress(types.TestTokenContractAddress)
 	testEthereumAddress := types.NewEthereumAddress(types.TestEthereumAddress)
@@ -245,8 +527,8 @@ func TestBurnEthSuccess(t *testing.T) {
 	require.True(t, receiverCoins.IsEqual(mintedCoins))
 
 	coinsToBurnAmount := int64(3)
-	coinsToBurnSymbol := "ether"
-	coinsToBurnSymbolPrefixed := fmt.Sprintf("%v%v", types.PeggedCoinPrefix, coinsToBurnSymbol)
+	coinsToBurnSymbol := types.TestCoinsSymbol
+	coinsToBurnSymbolPrefixed := fmt.Sprintf("%v%v", types.DefaultPeggedCoinPrefix, coinsToBurnSymbol)
 
 	ethereumReceiver := types.NewEthereumAddress(types.AltTestEthereumAddress)
 
@@ -356,3 +638,136 @@ func TestBurnEthSuccess(t *testing.T) {
 	senderCoins = bankKeeper.GetCoins(ctx, senderAddress)
 	require.True(t, senderCoins.IsEqual(remainingCoins))
 }
+
+func TestBridgeConfiguration(t *testing.T) {
+	testBridgeContractAddress := types.NewEthereumAddress(types.TestBridgeContractAddress)
+	testTokenContractAddress := types.NewEthereumAddress(types.TestTokenContractAddress)
+	peggedCoinPrefix := "wrapped"
+	params := types.NewParams(
+		[]int{types.TestEthereumChainID},
+		[]types.EthereumAddress{testBridgeContractAddress},
+		peggedCoinPrefix,
+		[]types.RegisteredToken{
+			types.NewRegisteredToken(types.TestEthereumChainID, testTokenContractAddress, types.TestCoinsSymbol,
+				peggedCoinPrefix+types.TestCoinsSymbol, true),
+		},
+	)
+	ctx, _, bankKeeper, _, _, _, validatorAddresses, handler := CreateTestHandlerWithParams(t, 0.5, []int64{5}, params)
+	valAddress := validatorAddresses[0]
+
+	// Claims from an unaccepted ethereum chain are rejected
+	ethMsg := types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.EthereumChainID = types.TestEthereumChainID + 1
+	_, err := handler(ctx, ethMsg)
+	require.True(t, types.ErrInvalidEthereumChainID.Is(err))
+
+	// Claims from an unaccepted bridge contract are rejected
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.BridgeContractAddress = types.NewEthereumAddress(types.AltTestEthereumAddress)
+	_, err = handler(ctx, ethMsg)
+	require.True(t, types.ErrInvalidBridgeContract.Is(err))
+
+	// Claims about a registered token must carry its symbol
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.Symbol = "ether"
+	_, err = handler(ctx, ethMsg)
+	require.True(t, types.ErrInvalidTokenSymbol.Is(err))
+
+	// Locked assets are minted with the denom of their registered token
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	_, err = handler(ctx, ethMsg)
+	require.NoError(t, err)
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	peggedSymbol := peggedCoinPrefix + types.TestCoinsSymbol
+	require.Equal(t, sdk.NewInt(types.TestCoinsAmount), bankKeeper.GetCoins(ctx, receiverAddress).AmountOf(peggedSymbol))
+
+	// Burns must use the configured prefix and an accepted ethereum chain
+	ethereumReceiver := types.NewEthereumAddress(types.AltTestEthereumAddress)
+	burnMsg := types.CreateTestBurnMsg(t, types.TestAddress, ethereumReceiver, 1, types.TestCoinsLockedSymbol)
+	_, err = handler(ctx, burnMsg)
+	require.True(t, types.ErrInvalidBurnSymbol.Is(err))
+	burnMsg = types.CreateTestBurnMsg(t, types.TestAddress, ethereumReceiver, 1, peggedCoinPrefix)
+	_, err = handler(ctx, burnMsg)
+	require.True(t, types.ErrInvalidBurnSymbol.Is(err))
+
+	burnMsg = types.CreateTestBurnMsg(t, types.TestAddress, ethereumReceiver, 1, peggedSymbol)
+	burnMsg.EthereumChainID = types.TestEthereumChainID + 1
+	_, err = handler(ctx, burnMsg)
+	require.True(t, types.ErrInvalidEthereumChainID.Is(err))
+
+	burnMsg.EthereumChainID = types.TestEthereumChainID
+	_, err = handler(ctx, burnMsg)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(types.TestCoinsAmount-1), bankKeeper.GetCoins(ctx, receiverAddress).AmountOf(peggedSymbol))
+}
+
+func TestTokenRegistry(t *testing.T) {
+	tetherAddress := types.NewEthereumAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	fakeTetherAddress := types.NewEthereumAddress(types.AltTestEthereumAddress)
+	params := types.CreateTestParams()
+	params.TokenRegistry = append(params.TokenRegistry,
+		types.NewRegisteredToken(types.TestEthereumChainID, tetherAddress, "USDT", "peggyusdt", true),
+		types.NewRegisteredToken(types.TestEthereumChainID, fakeTetherAddress, "USDT", "peggyfakeusdt", false),
+		types.NewRegisteredToken(types.TestEthereumChainID, types.NewEthereumAddress(types.TestEthereumAddress),
+			"STAKE", "stake", true),
+	)
+	ctx, _, bankKeeper, _, _, bridgeKeeper, validatorAddresses, handler :=
+		CreateTestHandlerWithParams(t, 0.5, []int64{5}, params)
+	valAddress := validatorAddresses[0]
+	receiverAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+
+	// Claims about unregistered or disabled tokens are rejected
+	ethMsg := types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.TokenContractAddress = types.NewEthereumAddress(types.TestBridgeContractAddress)
+	_, err = handler(ctx, ethMsg)
+	require.True(t, types.ErrUnregisteredToken.Is(err))
+
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.TokenContractAddress = fakeTetherAddress
+	ethMsg.Symbol = "USDT"
+	_, err = handler(ctx, ethMsg)
+	require.True(t, types.ErrTokenDisabled.Is(err))
+
+	// Tokens native to ethereum are only bridged by lock claims, and tokens native to cosmos by burn claims
+	_, err = handler(ctx, types.CreateTestEthMsg(t, valAddress, types.BurnText))
+	require.True(t, types.ErrInvalidClaimType.Is(err))
+
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.TokenContractAddress = types.NewEthereumAddress(types.TestEthereumAddress)
+	ethMsg.Symbol = "STAKE"
+	_, err = handler(ctx, ethMsg)
+	require.True(t, types.ErrInvalidClaimType.Is(err))
+
+	// The registered token decides which coins are minted, whatever symbol its contract has
+	ethMsg = types.CreateTestEthMsg(t, valAddress, types.LockText)
+	ethMsg.TokenContractAddress = tetherAddress
+	ethMsg.Symbol = "USDT"
+	_, err = handler(ctx, ethMsg)
+	require.NoError(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("peggyusdt", types.TestCoinsAmount)},
+		bankKeeper.GetCoins(ctx, receiverAddress))
+
+	// Tokens disabled by governance once claims were made on them are not minted
+	params.TokenRegistry[1].Enabled = false
+	bridgeKeeper.SetParams(ctx, params)
+	oracleClaim, err := CreateOracleClaimFromEthClaim(ModuleCdc, EthBridgeClaim(ethMsg))
+	require.NoError(t, err)
+	require.True(t, types.ErrTokenDisabled.Is(bridgeKeeper.ProcessSuccessfulClaim(ctx, oracleClaim.Content)))
+
+	// Nor are tokens of an ethereum chain that is no longer accepted, as parameter changes are validated one key at a
+	// time
+	params.TokenRegistry[1].Enabled = true
+	params.EthereumChainIDs = []int{types.TestEthereumChainID + 1}
+	bridgeKeeper.SetParams(ctx, params)
+	require.Error(t, params.Validate())
+	require.True(t, types.ErrInvalidEthereumChainID.Is(bridgeKeeper.ProcessSuccessfulClaim(ctx, oracleClaim.Content)))
+
+	// Pegged coins cannot be locked, burn claims would never release them
+	lockMsg := types.NewMsgLock(types.TestEthereumChainID+1, receiverAddress,
+		types.NewEthereumAddress(types.AltTestEthereumAddress), sdk.NewInt(1), "peggyusdt")
+	_, err = handler(ctx, lockMsg)
+	require.True(t, types.ErrInvalidSymbol.Is(err))
+	require.Equal(t, sdk.NewInt(types.TestCoinsAmount), bankKeeper.GetCoins(ctx, receiverAddress).AmountOf("peggyusdt"))
+}
@@ -0,0 +1,63 @@
+package ethbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+func TestInvariants(t *testing.T) {
+	ctx, _, _, supplyKeeper, _, bridgeKeeper, validatorAddresses, handler := CreateTestHandlerWithParams(
+		t, 0.5, []int64{5}, types.CreateTestParams())
+	userAddress, err := sdk.AccAddressFromBech32(types.TestAddress)
+	require.NoError(t, err)
+	userCoins := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	require.NoError(t, supplyKeeper.MintCoins(ctx, ModuleName, userCoins))
+	require.NoError(t, supplyKeeper.SendCoinsFromModuleToAccount(ctx, ModuleName, userAddress, userCoins))
+
+	// Locks, lock claims and burns keep the coins tracked by the bridge in line with its escrow and pegged supply
+	_, err = handler(ctx, types.NewMsgLock(types.TestEthereumChainID, userAddress,
+		NewEthereumAddress(types.AltTestEthereumAddress), sdk.NewInt(10), "stake"))
+	require.NoError(t, err)
+	_, err = handler(ctx, types.CreateTestEthMsg(t, validatorAddresses[0], types.LockText))
+	require.NoError(t, err)
+	_, err = handler(ctx, types.CreateTestBurnMsg(t, types.TestAddress,
+		NewEthereumAddress(types.AltTestEthereumAddress), 3, "peggyeth"))
+	require.NoError(t, err)
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), bridgeKeeper.GetLockedCoins(ctx))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("peggyeth", types.TestCoinsAmount-3)),
+		bridgeKeeper.GetPeggedCoins(ctx))
+	_, broken := AllInvariants(bridgeKeeper)(ctx)
+	require.False(t, broken)
+
+	// Coins leaving the escrow other than through burn claims break the escrow invariant
+	cacheCtx, _ := ctx.CacheContext()
+	require.NoError(t, supplyKeeper.SendCoinsFromModuleToAccount(cacheCtx, ModuleName, userAddress,
+		sdk.NewCoins(sdk.NewInt64Coin("stake", 1))))
+	msg, broken := EscrowInvariant(bridgeKeeper)(cacheCtx)
+	require.True(t, broken)
+	require.Contains(t, msg, "locked coins:   10stake")
+	_, broken = PeggedSupplyInvariant(bridgeKeeper)(cacheCtx)
+	require.False(t, broken)
+
+	// Pegged coins minted other than for lock claims break the pegged supply invariant, even in a new denom
+	for _, denom := range []string{"peggyeth", "peggyusdt"} {
+		cacheCtx, _ = ctx.CacheContext()
+		require.NoError(t, supplyKeeper.MintCoins(cacheCtx, ModuleName, sdk.NewCoins(sdk.NewInt64Coin(denom, 1))))
+		msg, broken = PeggedSupplyInvariant(bridgeKeeper)(cacheCtx)
+		require.True(t, broken)
+		require.Contains(t, msg, "1 pegged denoms with a supply different from the amount minted found")
+		_, broken = EscrowInvariant(bridgeKeeper)(cacheCtx)
+		require.False(t, broken)
+	}
+
+	// Coins native to cosmos can still be minted
+	require.NoError(t, supplyKeeper.MintCoins(ctx, ModuleName, sdk.NewCoins(sdk.NewInt64Coin("stake", 1))))
+	_, broken = AllInvariants(bridgeKeeper)(ctx)
+	require.False(t, broken)
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+// GetLockedCoins returns the coins locked in the bridge escrow by MsgLock, net of those returned by burn claims
+func (k Keeper) GetLockedCoins(ctx sdk.Context) sdk.Coins {
+	return k.getCoins(ctx, types.LockedCoinsKey)
+}
+
+// SetLockedCoins sets the coins locked in the bridge escrow, see GetLockedCoins
+func (k Keeper) SetLockedCoins(ctx sdk.Context, coins sdk.Coins) {
+	k.setCoins(ctx, types.LockedCoinsKey, coins)
+}
+
+// GetPeggedCoins returns the pegged coins minted for lock claims, net of those burned by MsgBurn
+func (k Keeper) GetPeggedCoins(ctx sdk.Context) sdk.Coins {
+	return k.getCoins(ctx, types.PeggedCoinsKey)
+}
+
+// SetPeggedCoins sets the pegged coins minted by the bridge, see GetPeggedCoins
+func (k Keeper) SetPeggedCoins(ctx sdk.Context, coins sdk.Coins) {
+	k.setCoins(ctx, types.PeggedCoinsKey, coins)
+}
+
+// SetBridgedCoinsFromBalances sets the coins locked in the bridge escrow to its balance and the pegged coins minted
+// by the bridge to the supply of pegged denoms, for chains whose bridge did not track them
+func (k Keeper) SetBridgedCoinsFromBalances(ctx sdk.Context) {
+	k.SetLockedCoins(ctx, k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins())
+
+	params := k.GetParams(ctx)
+	var peggedCoins sdk.Coins
+	for _, coin := range k.supplyKeeper.GetSupply(ctx).GetTotal() {
+		if params.IsPeggedDenom(coin.Denom) {
+			peggedCoins = append(peggedCoins, coin)
+		}
+	}
+	k.SetPeggedCoins(ctx, peggedCoins)
+}
+
+func (k Keeper) getCoins(ctx sdk.Context, key []byte) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(key)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+
+	var coins sdk.Coins
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &coins)
+	return coins
+}
+
+func (k Keeper) setCoins(ctx sdk.Context, key []byte, coins sdk.Coins) {
+	ctx.KVStore(k.storeKey).Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(coins))
+}
@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
+)
+
+// Hooks wrapper struct for the ethbridge keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ oracle.OracleHooks = Hooks{}
+
+// Hooks returns the oracle hooks of the ethbridge keeper, which process the claims of prophecies once they succeed
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterProphecySucceeded implements the oracle.OracleHooks interface, only the prophecies of the ethbridge
+// namespace are processed
+func (h Hooks) AfterProphecySucceeded(ctx sdk.Context, namespace, _ string, finalClaim string) error {
+	if namespace != types.ModuleName {
+		return nil
+	}
+	return h.k.ProcessSuccessfulClaim(ctx, finalClaim)
+}
+
+// AfterProphecyFailed implements the oracle.OracleHooks interface, nothing was minted or unlocked for failed
+// prophecies so there is nothing to do
+func (h Hooks) AfterProphecyFailed(_ sdk.Context, _, _ string, _ oracle.StatusText) error {
+	return nil
+}
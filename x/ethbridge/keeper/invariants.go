@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+// RegisterInvariants registers the ethbridge module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "escrow", EscrowInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "pegged-supply", PeggedSupplyInvariant(k))
+}
+
+// AllInvariants runs all invariants of the ethbridge module
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := EscrowInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return PeggedSupplyInvariant(k)(ctx)
+	}
+}
+
+// EscrowInvariant checks that the bridge escrow holds at least the coins locked in it that were not returned yet
+func EscrowInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		escrowed := k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+		locked := k.GetLockedCoins(ctx)
+
+		broken := !escrowed.IsAllGTE(locked)
+		return sdk.FormatInvariant(types.ModuleName, "escrow", fmt.Sprintf(
+			"\tescrowed coins: %v\n"+
+				"\tlocked coins:   %v\n", escrowed, locked)), broken
+	}
+}
+
+// PeggedSupplyInvariant checks that the supply of each pegged denom is the amount minted for lock claims net of the
+// amount burned. Denoms that were minted by the bridge are checked even if they no longer have the pegged coin
+// prefix.
+func PeggedSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		params := k.GetParams(ctx)
+		supply := k.supplyKeeper.GetSupply(ctx).GetTotal()
+		pegged := k.GetPeggedCoins(ctx)
+
+		var msg string
+		var count int
+		check := func(denom string) {
+			if supplied, minted := supply.AmountOf(denom), pegged.AmountOf(denom); !supplied.Equal(minted) {
+				count++
+				msg += fmt.Sprintf("\t%s supply is %s, %s minted by the bridge\n", denom, supplied, minted)
+			}
+		}
+		for _, coin := range pegged {
+			check(coin.Denom)
+		}
+		for _, coin := range supply {
+			if params.IsPeggedDenom(coin.Denom) && pegged.AmountOf(coin.Denom).IsZero() {
+				check(coin.Denom)
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "pegged-supply", fmt.Sprintf(
+			"%d pegged denoms with a supply different from the amount minted found\n%s", count, msg)), broken
+	}
+}
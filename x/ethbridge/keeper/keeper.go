@@ -2,6 +2,9 @@ package keeper
 
 import (
 	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -9,23 +12,36 @@ import (
 	"github.com/sifchain/peggy/x/oracle"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/params"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
+// ethereumAddressLength is the length of a hex-encoded ethereum address with its 0x prefix
+const ethereumAddressLength = 2 + 2*common.AddressLength
+
 // Keeper maintains the link to data storage and
 // exposes getter/setter methods for the various parts of the state machine
 type Keeper struct {
-	cdc *codec.Codec // The wire codec for binary encoding/decoding.
+	cdc      *codec.Codec // The wire codec for binary encoding/decoding.
+	storeKey sdk.StoreKey // Unexposed key to access the store from sdk.Context
+
+	paramSpace params.Subspace // The ethbridge module parameter subspace
 
 	supplyKeeper types.SupplyKeeper
 	oracleKeeper types.OracleKeeper
 }
 
-// NewKeeper creates new instances of the oracle Keeper
-func NewKeeper(cdc *codec.Codec, supplyKeeper types.SupplyKeeper, oracleKeeper types.OracleKeeper) Keeper {
+// NewKeeper creates new instances of the ethbridge Keeper
+func NewKeeper(
+	cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, supplyKeeper types.SupplyKeeper,
+	oracleKeeper types.OracleKeeper,
+) Keeper {
 	return Keeper{
 		cdc:          cdc,
+		storeKey:     storeKey,
+		paramSpace:   paramSpace.WithKeyTable(types.ParamKeyTable()),
 		supplyKeeper: supplyKeeper,
 		oracleKeeper: oracleKeeper,
 	}
@@ -38,14 +54,87 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 
 // ProcessClaim processes a new claim coming in from a validator
 func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.EthBridgeClaim) (oracle.Status, error) {
+	if err := k.validateClaim(ctx, claim); err != nil {
+		return oracle.Status{}, err
+	}
+
 	oracleClaim, err := types.CreateOracleClaimFromEthClaim(k.cdc, claim)
 	if err != nil {
 		return oracle.Status{}, err
 	}
+	oracleClaim.ID, err = k.prophecyID(ctx, claim.EthereumChainID, claim.BridgeContractAddress, claim.Nonce,
+		claim.EthereumSender)
+	if err != nil {
+		return oracle.Status{}, err
+	}
 
 	return k.oracleKeeper.ProcessClaim(ctx, oracleClaim)
 }
 
+// ProcessCommit processes the commitment of a validator to the claim it will reveal on the prophecy of an ethereum
+// event
+func (k Keeper) ProcessCommit(ctx sdk.Context, commit types.MsgCommitEthBridgeClaim) (oracle.Status, error) {
+	if err := k.validateProphecySource(ctx, commit.EthereumChainID, commit.BridgeContractAddress); err != nil {
+		return oracle.Status{}, err
+	}
+
+	id, err := k.prophecyID(ctx, commit.EthereumChainID, commit.BridgeContractAddress, commit.Nonce,
+		commit.EthereumSender)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+	return k.oracleKeeper.ProcessCommit(ctx,
+		oracle.NewClaimCommit(types.ModuleName, id, commit.ValidatorAddress, commit.Commitment))
+}
+
+// ProcessReveal processes a claim revealed by a validator that committed to it with the given salt
+func (k Keeper) ProcessReveal(ctx sdk.Context, claim types.EthBridgeClaim, salt string) (oracle.Status, error) {
+	if err := k.validateClaim(ctx, claim); err != nil {
+		return oracle.Status{}, err
+	}
+
+	oracleClaim, err := types.CreateOracleClaimFromEthClaim(k.cdc, claim)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+	oracleClaim.ID, err = k.prophecyID(ctx, claim.EthereumChainID, claim.BridgeContractAddress, claim.Nonce,
+		claim.EthereumSender)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+
+	return k.oracleKeeper.RevealClaim(ctx, oracleClaim, salt)
+}
+
+// ProcessRejection processes the vote of a validator to reject the prophecy of an ethereum event it could not
+// observe, or observed differently from every claim made on it
+func (k Keeper) ProcessRejection(ctx sdk.Context, rejection types.MsgRejectEthBridgeClaim) (oracle.Status, error) {
+	if err := k.validateProphecySource(ctx, rejection.EthereumChainID, rejection.BridgeContractAddress); err != nil {
+		return oracle.Status{}, err
+	}
+
+	id, err := k.prophecyID(ctx, rejection.EthereumChainID, rejection.BridgeContractAddress, rejection.Nonce,
+		rejection.EthereumSender)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+	return k.oracleKeeper.ProcessRejection(ctx, types.ModuleName, id, rejection.ValidatorAddress)
+}
+
+// prophecyID returns the id of the prophecy of the claims made on the given ethereum event. Events that may be the
+// event of a legacy prophecy MigrateProphecies could not map are rejected, as their claims could not tell whether
+// that prophecy is theirs.
+func (k Keeper) prophecyID(
+	ctx sdk.Context, ethereumChainID int, bridgeContract types.EthereumAddress, nonce int,
+	ethereumSender types.EthereumAddress,
+) (string, error) {
+	id := types.ProphecyID(ethereumChainID, bridgeContract, nonce, ethereumSender)
+	if ctx.KVStore(k.storeKey).Has(types.AmbiguousEventKey(ethereumChainID, nonce, ethereumSender)) {
+		return "", sdkerrors.Wrap(types.ErrAmbiguousProphecyID, id)
+	}
+	return id, nil
+}
+
 // ProcessSuccessfulClaim processes a claim that has just completed successfully with consensus
 func (k Keeper) ProcessSuccessfulClaim(ctx sdk.Context, claim string) error {
 	oracleClaim, err := types.CreateOracleClaimFromOracleString(claim)
@@ -55,21 +144,31 @@ func (k Keeper) ProcessSuccessfulClaim(ctx sdk.Context, claim string) error {
 
 	receiverAddress := oracleClaim.CosmosReceiver
 
-	var coins sdk.Coins
+	// the registered token decides which coins are bridged, the token may have been disabled since the claims were
+	// made
+	token, err := validateClaimToken(k.GetParams(ctx), oracleClaim.EthereumChainID,
+		oracleClaim.TokenContractAddress, oracleClaim.Symbol, oracleClaim.ClaimType)
+	if err != nil {
+		return err
+	}
+
+	coins := sdk.Coins{sdk.NewCoin(token.Denom, oracleClaim.Amount)}
 	switch oracleClaim.ClaimType {
 	case types.LockText:
-		symbol := fmt.Sprintf("%v%v", types.PeggedCoinPrefix, oracleClaim.Symbol)
-		coins = sdk.Coins{sdk.NewInt64Coin(symbol, oracleClaim.Amount)}
-		err = k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins)
+		// assets locked on ethereum are represented by pegged coins minted for them
+		if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+			return err
+		}
+		k.SetPeggedCoins(ctx, k.GetPeggedCoins(ctx).Add(coins...))
 	case types.BurnText:
-		coins = sdk.Coins{sdk.NewInt64Coin(oracleClaim.Symbol, oracleClaim.Amount)}
-		err = k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins)
-	default:
-		err = types.ErrInvalidClaimType
-	}
-
-	if err != nil {
-		return err
+		// coins native to cosmos come back from the escrow they were locked in when they left for ethereum, only
+		// what was locked through the bridge can be returned
+		lockedCoins := k.GetLockedCoins(ctx)
+		if locked := lockedCoins.AmountOf(token.Denom); locked.LT(oracleClaim.Amount) {
+			return sdkerrors.Wrapf(types.ErrInsufficientEscrow, "%s%s locked, %s claimed",
+				locked, token.Denom, coins)
+		}
+		k.SetLockedCoins(ctx, lockedCoins.Sub(coins))
 	}
 
 	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(
@@ -81,8 +180,79 @@ func (k Keeper) ProcessSuccessfulClaim(ctx sdk.Context, claim string) error {
 	return nil
 }
 
+// legacyEvent is an ethereum event a prophecy stored under a legacy id may be the prophecy of
+type legacyEvent struct {
+	ethereumChainID int
+	nonce           int
+	ethereumSender  types.EthereumAddress
+}
+
+// MigrateProphecies moves the prophecies made before prophecies were namespaced, which were all made by the ethbridge
+// module, into the ethbridge namespace under ids covering their bridge contract. Legacy ids concatenate the ethereum
+// chain id, the nonce and the ethereum sender, so they are only mapped when a single accepted ethereum chain id
+// splits them and a single bridge contract is accepted. The others keep their legacy id, and claims on every event
+// they may be the prophecy of are rejected. It must be called once, by the upgrade handler of the chain.
+func (k Keeper) MigrateProphecies(ctx sdk.Context) int {
+	params := k.GetParams(ctx)
+	store := ctx.KVStore(k.storeKey)
+	return k.oracleKeeper.MigrateStore(ctx, types.ModuleName, func(id string) (string, bool) {
+		events := migrateLegacyProphecyID(params, id)
+		if len(events) == 1 && len(params.BridgeContractAddresses) == 1 {
+			event := events[0]
+			return types.ProphecyID(event.ethereumChainID, params.BridgeContractAddresses[0], event.nonce,
+				event.ethereumSender), true
+		}
+		for _, event := range events {
+			store.Set(types.AmbiguousEventKey(event.ethereumChainID, event.nonce, event.ethereumSender), []byte(id))
+		}
+		return "", false
+	})
+}
+
+// migrateLegacyProphecyID returns every accepted ethereum event the prophecy with the given legacy id may be the
+// prophecy of
+func migrateLegacyProphecyID(params types.Params, id string) []legacyEvent {
+	if len(id) <= ethereumAddressLength {
+		return nil
+	}
+	digits, sender := id[:len(id)-ethereumAddressLength], id[len(id)-ethereumAddressLength:]
+	if !common.IsHexAddress(sender) {
+		return nil
+	}
+	ethereumSender := types.NewEthereumAddress(sender)
+
+	var events []legacyEvent
+	for i := 1; i < len(digits); i++ {
+		ethereumChainID, err := strconv.Atoi(digits[:i])
+		if err != nil || !params.IsAcceptedEthereumChainID(ethereumChainID) {
+			continue
+		}
+		nonce, err := strconv.Atoi(digits[i:])
+		if err != nil || types.LegacyProphecyID(ethereumChainID, nonce, ethereumSender) != id {
+			continue
+		}
+		events = append(events, legacyEvent{ethereumChainID, nonce, ethereumSender})
+	}
+	return events
+}
+
 // ProcessBurn processes the burn of bridged coins from the given sender
 func (k Keeper) ProcessBurn(ctx sdk.Context, cosmosSender sdk.AccAddress, amount sdk.Coins) error {
+	params := k.GetParams(ctx)
+	for _, coin := range amount {
+		if !params.IsPeggedDenom(coin.Denom) {
+			return sdkerrors.Wrapf(types.ErrInvalidBurnSymbol, "%s does not have the pegged coin prefix %s",
+				coin.Denom, params.PeggedCoinPrefix)
+		}
+	}
+
+	// only the pegged coins minted by the bridge can be burned to leave for ethereum
+	peggedCoins := k.GetPeggedCoins(ctx)
+	remainingCoins, isNegative := peggedCoins.SafeSub(amount)
+	if isNegative {
+		return sdkerrors.Wrapf(types.ErrInsufficientPeggedCoins, "%s minted, %s burned", peggedCoins, amount)
+	}
+
 	if err := k.supplyKeeper.SendCoinsFromAccountToModule(
 		ctx, cosmosSender, types.ModuleName, amount,
 	); err != nil {
@@ -92,11 +262,105 @@ func (k Keeper) ProcessBurn(ctx sdk.Context, cosmosSender sdk.AccAddress, amount
 	if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, amount); err != nil {
 		panic(err)
 	}
+	k.SetPeggedCoins(ctx, remainingCoins)
 
 	return nil
 }
 
 // ProcessLock processes the lockup of cosmos coins from the given sender
 func (k Keeper) ProcessLock(ctx sdk.Context, cosmosSender sdk.AccAddress, amount sdk.Coins) error {
-	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, cosmosSender, types.ModuleName, amount)
+	// pegged coins are native to ethereum, they go back by being burned as burn claims never release them
+	params := k.GetParams(ctx)
+	for _, coin := range amount {
+		if params.IsPeggedDenom(coin.Denom) {
+			return sdkerrors.Wrapf(types.ErrInvalidSymbol, "%s has the pegged coin prefix %s and can only be burned",
+				coin.Denom, params.PeggedCoinPrefix)
+		}
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(
+		ctx, cosmosSender, types.ModuleName, amount,
+	); err != nil {
+		return err
+	}
+
+	k.SetLockedCoins(ctx, k.GetLockedCoins(ctx).Add(amount...))
+	return nil
+}
+
+// ValidateEthereumChainID returns an error if the given ethereum chain id is not accepted by the bridge
+func (k Keeper) ValidateEthereumChainID(ctx sdk.Context, ethereumChainID int) error {
+	if !k.GetParams(ctx).IsAcceptedEthereumChainID(ethereumChainID) {
+		return sdkerrors.Wrapf(types.ErrInvalidEthereumChainID, "%d is not accepted by the bridge", ethereumChainID)
+	}
+	return nil
+}
+
+// validateProphecySource checks the ethereum chain id and bridge contract of a prophecy against the bridge
+// configuration
+func (k Keeper) validateProphecySource(
+	ctx sdk.Context, ethereumChainID int, bridgeContract types.EthereumAddress,
+) error {
+	params := k.GetParams(ctx)
+	if !params.IsAcceptedEthereumChainID(ethereumChainID) {
+		return sdkerrors.Wrapf(types.ErrInvalidEthereumChainID, "%d is not accepted by the bridge", ethereumChainID)
+	}
+	if !params.IsAcceptedBridgeContract(bridgeContract) {
+		return sdkerrors.Wrap(types.ErrInvalidBridgeContract, bridgeContract.String())
+	}
+	return nil
+}
+
+// validateClaim checks a claim against the bridge configuration before it reaches the oracle
+func (k Keeper) validateClaim(ctx sdk.Context, claim types.EthBridgeClaim) error {
+	if err := k.validateProphecySource(ctx, claim.EthereumChainID, claim.BridgeContractAddress); err != nil {
+		return err
+	}
+	_, err := validateClaimToken(k.GetParams(ctx), claim.EthereumChainID, claim.TokenContractAddress, claim.Symbol,
+		claim.ClaimType)
+	return err
+}
+
+// validateClaimToken checks the token a claim is about against the token registry and returns its registry entry.
+// The token must be registered on an accepted ethereum chain and enabled, and claims must carry its symbol. Only
+// tokens native to Ethereum, whose denom has the pegged coin prefix, are bridged by lock claims while only tokens
+// native to Cosmos are bridged back by burn claims. The registry is checked against the other parameters here, as
+// parameter changes are validated one key at a time.
+func validateClaimToken(
+	params types.Params, ethereumChainID int, tokenContract types.EthereumAddress, symbol string,
+	claimType types.ClaimType,
+) (types.RegisteredToken, error) {
+	token, found := params.GetRegisteredToken(ethereumChainID, tokenContract)
+	if !found {
+		return types.RegisteredToken{}, sdkerrors.Wrapf(types.ErrUnregisteredToken, "%s on ethereum chain %d",
+			tokenContract, ethereumChainID)
+	}
+	if !params.IsAcceptedEthereumChainID(token.EthereumChainID) {
+		return types.RegisteredToken{}, sdkerrors.Wrapf(types.ErrInvalidEthereumChainID,
+			"registered token %s refers to an unaccepted ethereum chain id", token)
+	}
+	if !token.Enabled {
+		return types.RegisteredToken{}, sdkerrors.Wrap(types.ErrTokenDisabled, token.String())
+	}
+	if token.Symbol != symbol {
+		return types.RegisteredToken{}, sdkerrors.Wrapf(types.ErrInvalidTokenSymbol, "expected %s for %s, got %s",
+			token.Symbol, tokenContract, symbol)
+	}
+
+	switch claimType {
+	case types.LockText:
+		if !params.IsPeggedDenom(token.Denom) {
+			return types.RegisteredToken{}, sdkerrors.Wrapf(types.ErrInvalidClaimType,
+				"%s is native to cosmos and cannot be bridged by a lock claim", token.Denom)
+		}
+	case types.BurnText:
+		if params.IsPeggedDenom(token.Denom) {
+			return types.RegisteredToken{}, sdkerrors.Wrapf(types.ErrInvalidClaimType,
+				"%s is native to ethereum and cannot be bridged by a burn claim", token.Denom)
+		}
+	default:
+		return types.RegisteredToken{}, types.ErrInvalidClaimType
+	}
+
+	return token, nil
 }
@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/ethbridge/types"
+)
+
+// GetParams returns the total set of ethbridge parameters. Parameters that were never set, as on chains upgraded in
+// place from a version without them, have their default value.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	params = types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+// SetParams sets the total set of ethbridge parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// GetPeggedCoinPrefix returns the prefix of the denom of coins minted for assets locked on Ethereum
+func (k Keeper) GetPeggedCoinPrefix(ctx sdk.Context) (peggedCoinPrefix string) {
+	peggedCoinPrefix = types.DefaultPeggedCoinPrefix
+	k.paramSpace.GetIfExists(ctx, types.KeyPeggedCoinPrefix, &peggedCoinPrefix)
+	return peggedCoinPrefix
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
+)
+
+// HandleResolveProphecyProposal resolves the prophecy of an ethereum event as decided by governance. Its final
+// claim, if any, is processed by the oracle hooks like the final claim of any other successful prophecy.
+func HandleResolveProphecyProposal(ctx sdk.Context, k Keeper, p types.ResolveProphecyProposal) error {
+	if err := k.validateProphecySource(ctx, p.EthereumChainID, p.BridgeContractAddress); err != nil {
+		return err
+	}
+	params := k.GetParams(ctx)
+
+	id := types.ProphecyID(p.EthereumChainID, p.BridgeContractAddress, p.Nonce, p.EthereumSender)
+	resolveEvent := sdk.NewEvent(
+		types.EventTypeResolveClaim,
+		sdk.NewAttribute(types.AttributeKeyEthereumChainID, strconv.Itoa(p.EthereumChainID)),
+		sdk.NewAttribute(types.AttributeKeyEthereumSender, p.EthereumSender.String()),
+		sdk.NewAttribute(types.AttributeKeyProphecyID, id),
+	)
+
+	status := oracle.NewStatus(oracle.FailedStatusText, "")
+	if p.FinalClaim != nil {
+		finalClaim := *p.FinalClaim
+		if _, err := validateClaimToken(params, p.EthereumChainID, finalClaim.TokenContractAddress, finalClaim.Symbol,
+			finalClaim.ClaimType); err != nil {
+			return err
+		}
+
+		bz, err := json.Marshal(finalClaim)
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrJSONMarshalling, err.Error())
+		}
+		status = oracle.NewStatus(oracle.SuccessStatusText, string(bz))
+		resolveEvent = resolveEvent.AppendAttributes(
+			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, finalClaim.CosmosReceiver.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, finalClaim.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySymbol, finalClaim.Symbol),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, finalClaim.TokenContractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyClaimType, finalClaim.ClaimType.String()),
+		)
+	}
+
+	// the final claim of a prophecy resolved as successful is processed by the oracle hooks
+	if err := k.oracleKeeper.ResolveProphecy(ctx, types.ModuleName, id, status); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		resolveEvent,
+		sdk.NewEvent(
+			types.EventTypeProphecyStatus,
+			sdk.NewAttribute(types.AttributeKeyStatus, status.Text.String()),
+		),
+	})
+	return nil
+}
@@ -2,7 +2,6 @@ package keeper
 
 import (
 	"fmt"
-	"strconv"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
@@ -11,6 +10,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
 	oracletypes "github.com/sifchain/peggy/x/oracle/types"
 )
 
@@ -22,6 +22,8 @@ func NewQuerier(keeper types.OracleKeeper, cdc *codec.Codec) sdk.Querier {
 		switch path[0] {
 		case types.QueryEthProphecy:
 			return queryEthProphecy(ctx, cdc, req, keeper)
+		case types.QueryEthPropheciesByTxHash:
+			return queryEthPropheciesByTxHash(ctx, cdc, req, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown ethbridge query endpoint")
 		}
@@ -37,15 +39,16 @@ func queryEthProphecy(
 		return nil, sdkerrors.Wrap(types.ErrJSONMarshalling, fmt.Sprintf("failed to parse params: %s", err.Error()))
 	}
 
-	id := strconv.Itoa(params.EthereumChainID) + strconv.Itoa(params.Nonce) + params.EthereumSender.String()
-	prophecy, found := keeper.GetProphecy(ctx, id)
+	id := types.ProphecyID(params.EthereumChainID, params.BridgeContractAddress, params.Nonce,
+		params.EthereumSender)
+	prophecy, found := keeper.GetProphecy(ctx, types.ModuleName, id)
 	if !found {
 		return nil, sdkerrors.Wrap(oracletypes.ErrProphecyNotFound, id)
 	}
 
 	bridgeClaims, err := types.MapOracleClaimsToEthBridgeClaims(
 		params.EthereumChainID, params.BridgeContractAddress, params.Nonce, params.Symbol, params.TokenContractAddress,
-		params.EthereumSender, prophecy.ValidatorClaims, types.CreateEthClaimFromOracleString)
+		params.EthereumSender, prophecy.Claims, types.CreateEthClaimFromOracleString)
 	if err != nil {
 		return nil, err
 	}
@@ -54,3 +57,24 @@ func queryEthProphecy(
 
 	return cdc.MarshalJSONIndent(response, "", "  ")
 }
+
+func queryEthPropheciesByTxHash(
+	ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, keeper types.OracleKeeper,
+) ([]byte, error) {
+	var params types.QueryEthPropheciesByTxHashParams
+
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrJSONMarshalling, fmt.Sprintf("failed to parse params: %s", err.Error()))
+	}
+	if params.EthereumTxHash.Empty() {
+		return nil, types.ErrInvalidEthTxHash
+	}
+
+	prophecies := keeper.GetPropheciesByReference(ctx, types.ModuleName, params.EthereumTxHash.String())
+	if prophecies == nil {
+		prophecies = []oracle.Prophecy{}
+	}
+	response := types.NewQueryEthPropheciesByTxHashResponse(params.EthereumTxHash, prophecies)
+
+	return cdc.MarshalJSONIndent(response, "", "  ")
+}
@@ -14,7 +14,7 @@ import (
 
 //nolint:lll
 const (
-	TestResponseJSON = "{\"id\":\"300x7B95B6EC7EbD73572298cEf32Bb54FA408207359\",\"status\":{\"text\":\"pending\",\"final_claim\":\"\"},\"claims\":[{\"ethereum_chain_id\":3,\"bridge_registry_contract_address\":\"0xC4cE93a5699c68241fc2fB503Fb0f21724A624BB\",\"nonce\":0,\"symbol\":\"eth\",\"token_contract_address\":\"0x0000000000000000000000000000000000000000\",\"ethereum_sender\":\"0x7B95B6EC7EbD73572298cEf32Bb54FA408207359\",\"cosmos_receiver\":\"cosmos1gn8409qq9hnrxde37kuxwx5hrxpfpv8426szuv\",\"validator_address\":\"cosmosvaloper1mnfm9c7cdgqnkk66sganp78m0ydmcr4pn7fqfk\",\"amount\":10,\"claim_type\":\"lock\"}]}"
+	TestResponseJSON = "{\"id\":\"3:0xC4cE93a5699c68241fc2fB503Fb0f21724A624BB:0:0x7B95B6EC7EbD73572298cEf32Bb54FA408207359\",\"status\":{\"text\":\"pending\",\"final_claim\":\"\",\"resolved\":false},\"claims\":[{\"ethereum_chain_id\":3,\"bridge_registry_contract_address\":\"0xC4cE93a5699c68241fc2fB503Fb0f21724A624BB\",\"nonce\":0,\"symbol\":\"eth\",\"token_contract_address\":\"0x0000000000000000000000000000000000000000\",\"ethereum_sender\":\"0x7B95B6EC7EbD73572298cEf32Bb54FA408207359\",\"cosmos_receiver\":\"cosmos1gn8409qq9hnrxde37kuxwx5hrxpfpv8426szuv\",\"validator_address\":\"cosmosvaloper1mnfm9c7cdgqnkk66sganp78m0ydmcr4pn7fqfk\",\"amount\":\"10\",\"claim_type\":\"lock\",\"ethereum_tx_hash\":\"0x9e6ba5ba4e5d3ee1b1c6c2aaae4ae7bba6cf50fa5c17ec1e9caea5bbc81de1f2\",\"ethereum_block_number\":12,\"ethereum_log_index\":1}]}"
 )
 
 func TestNewQuerier(t *testing.T) {
@@ -36,6 +36,7 @@ func TestNewQuerier(t *testing.T) {
 
 func TestQueryEthProphecy(t *testing.T) {
 	ctx, oracleKeeper, _, _, _, validatorAddresses := oracle.CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	oracleKeeper.RegisterNamespace(types.ModuleName, types.OracleClaimContentType{})
 	cdc := keeperLib.MakeTestCodec()
 
 	valAddress := validatorAddresses[0]
@@ -114,3 +115,61 @@ func TestQueryEthProphecy(t *testing.T) {
 	_, err9 := queryEthProphecy(ctx, cdc, query3, oracleKeeper)
 	require.NotNil(t, err9)
 }
+
+func TestQueryEthPropheciesByTxHash(t *testing.T) {
+	ctx, oracleKeeper, _, _, _, validatorAddresses := oracle.CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	oracleKeeper.RegisterNamespace(types.ModuleName, types.OracleClaimContentType{})
+	cdc := keeperLib.MakeTestCodec()
+
+	testEthereumAddress := types.NewEthereumAddress(types.TestEthereumAddress)
+	testBridgeContractAddress := types.NewEthereumAddress(types.TestBridgeContractAddress)
+	testTokenContractAddress := types.NewEthereumAddress(types.TestTokenContractAddress)
+	testTxHash := types.NewEthereumTxHash(types.TestEthereumTxHash)
+
+	ethBridgeClaim := types.CreateTestEthClaim(
+		t, testBridgeContractAddress, testTokenContractAddress, validatorAddresses[0],
+		testEthereumAddress, types.TestCoinsAmount, types.TestCoinsSymbol, types.LockText)
+	oracleClaim, _ := types.CreateOracleClaimFromEthClaim(cdc, ethBridgeClaim)
+	_, err := oracleKeeper.ProcessClaim(ctx, oracleClaim)
+	require.NoError(t, err)
+
+	// a claim made on another transaction is not returned
+	otherEthBridgeClaim := ethBridgeClaim
+	otherEthBridgeClaim.Nonce++
+	otherEthBridgeClaim.EthereumTxHash = types.NewEthereumTxHash("0x01")
+	otherOracleClaim, _ := types.CreateOracleClaimFromEthClaim(cdc, otherEthBridgeClaim)
+	_, err = oracleKeeper.ProcessClaim(ctx, otherOracleClaim)
+	require.NoError(t, err)
+
+	bz, err := cdc.MarshalJSON(types.NewQueryEthPropheciesByTxHashParams(testTxHash))
+	require.NoError(t, err)
+	query := abci.RequestQuery{
+		Path: "/custom/ethbridge/prophecies_by_tx_hash",
+		Data: bz,
+	}
+
+	res, err := queryEthPropheciesByTxHash(ctx, cdc, query, oracleKeeper)
+	require.NoError(t, err)
+
+	var response types.QueryEthPropheciesByTxHashResponse
+	require.NoError(t, cdc.UnmarshalJSON(res, &response))
+	require.Equal(t, testTxHash, response.EthereumTxHash)
+	require.Len(t, response.Prophecies, 1)
+	require.Equal(t, oracleClaim.ID, response.Prophecies[0].ID)
+
+	// Test empty response for an unknown transaction
+	bz, err = cdc.MarshalJSON(types.NewQueryEthPropheciesByTxHashParams(types.NewEthereumTxHash("0x02")))
+	require.NoError(t, err)
+	query.Data = bz
+	res, err = queryEthPropheciesByTxHash(ctx, cdc, query, oracleKeeper)
+	require.NoError(t, err)
+	require.NoError(t, cdc.UnmarshalJSON(res, &response))
+	require.Empty(t, response.Prophecies)
+
+	// Test error with a missing transaction hash
+	bz, err = cdc.MarshalJSON(types.NewQueryEthPropheciesByTxHashParams(types.EthereumTxHash{}))
+	require.NoError(t, err)
+	query.Data = bz
+	_, err = queryEthPropheciesByTxHash(ctx, cdc, query, oracleKeeper)
+	require.True(t, types.ErrInvalidEthTxHash.Is(err))
+}
@@ -0,0 +1,122 @@
+package ethbridge
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/ethbridge/types"
+	"github.com/sifchain/peggy/x/oracle"
+	keeperLib "github.com/sifchain/peggy/x/oracle/keeper"
+)
+
+// createMigrateTestInput returns the test input of a chain running the first release of the bridge, whose oracle
+// store can be written in the legacy layout, with a bridge keeper using the given parameters
+func createMigrateTestInput(t *testing.T, params Params) (oracle.TestInput, Keeper, sdk.Handler) {
+	input := oracle.CreateTestInput(t, 0.7, []int64{3, 7}, ModuleName)
+	cdc := keeperLib.MakeTestCodec()
+	oracleKeeper := input.OracleKeeper
+	bridgeKeeper := NewKeeper(cdc, input.ModuleStoreKey, input.ParamsKeeper.Subspace(DefaultParamspace),
+		input.SupplyKeeper, &oracleKeeper)
+	oracleKeeper.RegisterNamespace(ModuleName, OracleClaimContentType{}).SetHooks(bridgeKeeper.Hooks())
+	bridgeKeeper.SetParams(input.Ctx, params)
+	input.OracleKeeper = oracleKeeper
+	return input, bridgeKeeper, NewHandler(input.AccountKeeper, bridgeKeeper, cdc)
+}
+
+// setLegacyProphecy stores a pending prophecy claimed by the validator under its legacy id, the way the first
+// release of the bridge stored them
+func setLegacyProphecy(t *testing.T, input oracle.TestInput, ethClaim EthBridgeClaim, validator sdk.ValAddress) string {
+	claim, err := CreateOracleClaimFromEthClaim(keeperLib.MakeTestCodec(), ethClaim)
+	require.NoError(t, err)
+	id := LegacyProphecyID(ethClaim.EthereumChainID, ethClaim.Nonce, ethClaim.EthereumSender)
+
+	claimValidators, err := json.Marshal(map[string][]sdk.ValAddress{claim.Content: {validator}})
+	require.NoError(t, err)
+	validatorClaims, err := json.Marshal(map[string]string{validator.String(): claim.Content})
+	require.NoError(t, err)
+	input.Ctx.KVStore(input.StoreKey).Set([]byte(id), keeperLib.MakeTestCodec().MustMarshalBinaryBare(
+		oracle.LegacyDBProphecy{
+			ID:              id,
+			Status:          oracle.NewStatus(oracle.PendingStatusText, ""),
+			ClaimValidators: claimValidators,
+			ValidatorClaims: validatorClaims,
+		},
+	))
+	return id
+}
+
+func TestMigrateProphecies(t *testing.T) {
+	input, bridgeKeeper, handler := createMigrateTestInput(t, types.CreateTestParams())
+	ctx, oracleKeeper, validatorAddresses := input.Ctx, input.OracleKeeper, input.ValidatorAddresses
+
+	ethClaim := EthBridgeClaim(types.CreateTestEthMsg(t, validatorAddresses[0], types.LockText))
+	legacyID := setLegacyProphecy(t, input, ethClaim, validatorAddresses[0])
+
+	require.Equal(t, 1, bridgeKeeper.MigrateProphecies(ctx))
+	require.False(t, ctx.KVStore(input.StoreKey).Has([]byte(legacyID)))
+	prophecy, found := oracleKeeper.GetProphecy(ctx, ModuleName, legacyID)
+	require.True(t, found)
+	require.Len(t, prophecy.Claims, 1)
+
+	// the legacy id is kept, as no bridge contract is configured, and claims on the event it may be the prophecy of
+	// are rejected rather than made on another prophecy
+	_, err := handler(ctx, types.CreateTestEthMsg(t, validatorAddresses[1], types.LockText))
+	require.True(t, ErrAmbiguousProphecyID.Is(err))
+	prophecy, _ = oracleKeeper.GetProphecy(ctx, ModuleName, legacyID)
+	require.Equal(t, oracle.PendingStatusText, prophecy.Status.Text)
+	require.False(t, oracleKeeper.HasProphecy(ctx, ModuleName, ProphecyID(ethClaim.EthereumChainID,
+		ethClaim.BridgeContractAddress, ethClaim.Nonce, ethClaim.EthereumSender)))
+}
+
+func TestMigrateProphecyIDs(t *testing.T) {
+	bridgeContract := NewEthereumAddress(types.TestBridgeContractAddress)
+	params := NewParams([]int{3, 31}, []EthereumAddress{bridgeContract}, DefaultPeggedCoinPrefix,
+		[]RegisteredToken{types.CreateTestRegisteredToken(), NewRegisteredToken(31,
+			NewEthereumAddress(types.TestTokenContractAddress), types.TestCoinsSymbol, "peggyeth31", true)})
+	input, bridgeKeeper, handler := createMigrateTestInput(t, params)
+	ctx, oracleKeeper, validatorAddresses := input.Ctx, input.OracleKeeper, input.ValidatorAddresses
+
+	// legacy ids concatenate the chain id, nonce and sender: "312" is either chain 3 and nonce 12 or chain 31 and
+	// nonce 2, while "30" can only be chain 3 and nonce 0
+	ethClaim := EthBridgeClaim(types.CreateTestEthMsg(t, validatorAddresses[0], types.LockText))
+	ambiguousEthClaim := ethClaim
+	ambiguousEthClaim.EthereumChainID = 31
+	ambiguousEthClaim.Nonce = 2
+	require.Equal(t, LegacyProphecyID(3, 12, ethClaim.EthereumSender),
+		LegacyProphecyID(ambiguousEthClaim.EthereumChainID, ambiguousEthClaim.Nonce, ethClaim.EthereumSender))
+	require.NotEqual(t, ProphecyID(3, bridgeContract, 12, ethClaim.EthereumSender),
+		ProphecyID(ambiguousEthClaim.EthereumChainID, bridgeContract, ambiguousEthClaim.Nonce, ethClaim.EthereumSender))
+
+	setLegacyProphecy(t, input, ethClaim, validatorAddresses[0])
+	ambiguousID := setLegacyProphecy(t, input, ambiguousEthClaim, validatorAddresses[0])
+
+	require.Equal(t, 2, bridgeKeeper.MigrateProphecies(ctx))
+	id := ProphecyID(ethClaim.EthereumChainID, bridgeContract, ethClaim.Nonce, ethClaim.EthereumSender)
+	require.True(t, oracleKeeper.HasProphecy(ctx, ModuleName, id))
+	require.True(t, oracleKeeper.HasProphecy(ctx, ModuleName, ambiguousID))
+
+	// claims are processed on the moved prophecy, and rejected on both events the prophecy that kept its ambiguous
+	// legacy id may be the prophecy of
+	_, err := handler(ctx, types.CreateTestEthMsg(t, validatorAddresses[1], types.LockText))
+	require.NoError(t, err)
+	prophecy, _ := oracleKeeper.GetProphecy(ctx, ModuleName, id)
+	require.Equal(t, oracle.SuccessStatusText, prophecy.Status.Text)
+
+	ambiguousMsg := NewMsgCreateEthBridgeClaim(ambiguousEthClaim)
+	ambiguousMsg.ValidatorAddress = validatorAddresses[1]
+	_, err = handler(ctx, ambiguousMsg)
+	require.True(t, ErrAmbiguousProphecyID.Is(err))
+	ambiguousMsg.EthereumChainID = 3
+	ambiguousMsg.Nonce = 12
+	_, err = handler(ctx, ambiguousMsg)
+	require.True(t, ErrAmbiguousProphecyID.Is(err))
+	_, err = handler(ctx, NewMsgRejectEthBridgeClaim(ambiguousEthClaim.EthereumChainID, bridgeContract,
+		ambiguousEthClaim.Nonce, ambiguousEthClaim.EthereumSender, validatorAddresses[1]))
+	require.True(t, ErrAmbiguousProphecyID.Is(err))
+	prophecy, _ = oracleKeeper.GetProphecy(ctx, ModuleName, ambiguousID)
+	require.Equal(t, oracle.PendingStatusText, prophecy.Status.Text)
+}
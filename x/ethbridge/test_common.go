@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/sifchain/peggy/x/ethbridge/types"
 	oracle "github.com/sifchain/peggy/x/oracle"
 	keeperLib "github.com/sifchain/peggy/x/oracle/keeper"
 )
@@ -15,14 +16,25 @@ import (
 func CreateTestHandler(
 	t *testing.T, consensusNeeded float64, validatorAmounts []int64,
 ) (sdk.Context, oracle.Keeper, bank.Keeper, supply.Keeper, auth.AccountKeeper, []sdk.ValAddress, sdk.Handler) {
-	ctx, oracleKeeper, bankKeeper, supplyKeeper,
-		accountKeeper, validatorAddresses := oracle.CreateTestKeepers(t, consensusNeeded, validatorAmounts, ModuleName)
-	bridgeAccount := supply.NewEmptyModuleAccount(ModuleName, supply.Burner, supply.Minter)
-	supplyKeeper.SetModuleAccount(ctx, bridgeAccount)
+	ctx, oracleKeeper, bankKeeper, supplyKeeper, accountKeeper, _, validatorAddresses, handler :=
+		CreateTestHandlerWithParams(t, consensusNeeded, validatorAmounts, types.CreateTestParams())
+
+	return ctx, oracleKeeper, bankKeeper, supplyKeeper, accountKeeper, validatorAddresses, handler
+}
+
+func CreateTestHandlerWithParams(
+	t *testing.T, consensusNeeded float64, validatorAmounts []int64, params Params,
+) (sdk.Context, oracle.Keeper, bank.Keeper, supply.Keeper, auth.AccountKeeper, Keeper, []sdk.ValAddress, sdk.Handler) {
+	input := oracle.CreateTestInput(t, consensusNeeded, validatorAmounts, ModuleName)
 
 	cdc := keeperLib.MakeTestCodec()
-	bridgeKeeper := NewKeeper(cdc, supplyKeeper, oracleKeeper)
-	handler := NewHandler(accountKeeper, bridgeKeeper, cdc)
+	oracleKeeper := input.OracleKeeper
+	bridgeKeeper := NewKeeper(cdc, input.ModuleStoreKey, input.ParamsKeeper.Subspace(DefaultParamspace),
+		input.SupplyKeeper, &oracleKeeper)
+	oracleKeeper.RegisterNamespace(ModuleName, OracleClaimContentType{}).SetHooks(bridgeKeeper.Hooks())
+	InitGenesis(input.Ctx, bridgeKeeper, input.SupplyKeeper, NewGenesisState(params, sdk.Coins{}, sdk.Coins{}))
+	handler := NewHandler(input.AccountKeeper, bridgeKeeper, cdc)
 
-	return ctx, oracleKeeper, bankKeeper, supplyKeeper, accountKeeper, validatorAddresses, handler
+	return input.Ctx, oracleKeeper, input.BankKeeper, input.SupplyKeeper, input.AccountKeeper, bridgeKeeper,
+		input.ValidatorAddresses, handler
 }
@@ -21,14 +21,19 @@ type EthBridgeClaim struct {
 	EthereumSender        EthereumAddress `json:"ethereum_sender" yaml:"ethereum_sender"`
 	CosmosReceiver        sdk.AccAddress  `json:"cosmos_receiver" yaml:"cosmos_receiver"`
 	ValidatorAddress      sdk.ValAddress  `json:"validator_address" yaml:"validator_address"`
-	Amount                int64           `json:"amount" yaml:"amount"`
+	Amount                sdk.Int         `json:"amount" yaml:"amount"`
 	ClaimType             ClaimType       `json:"claim_type" yaml:"claim_type"`
+	// EthereumTxHash, EthereumBlockNumber and EthereumLogIndex locate the log of the event claimed on ethereum
+	EthereumTxHash      EthereumTxHash `json:"ethereum_tx_hash" yaml:"ethereum_tx_hash"`
+	EthereumBlockNumber uint64         `json:"ethereum_block_number" yaml:"ethereum_block_number"`
+	EthereumLogIndex    uint64         `json:"ethereum_log_index" yaml:"ethereum_log_index"`
 }
 
 // NewEthBridgeClaim is a constructor function for NewEthBridgeClaim
 func NewEthBridgeClaim(ethereumChainID int, bridgeContract EthereumAddress,
 	nonce int, symbol string, tokenContact EthereumAddress, ethereumSender EthereumAddress,
-	cosmosReceiver sdk.AccAddress, validator sdk.ValAddress, amount int64, claimType ClaimType,
+	cosmosReceiver sdk.AccAddress, validator sdk.ValAddress, amount sdk.Int, claimType ClaimType,
+	ethereumTxHash EthereumTxHash, ethereumBlockNumber uint64, ethereumLogIndex uint64,
 ) EthBridgeClaim {
 	return EthBridgeClaim{
 		EthereumChainID:       ethereumChainID,
@@ -41,49 +46,154 @@ func NewEthBridgeClaim(ethereumChainID int, bridgeContract EthereumAddress,
 		ValidatorAddress:      validator,
 		Amount:                amount,
 		ClaimType:             claimType,
+		EthereumTxHash:        ethereumTxHash,
+		EthereumBlockNumber:   ethereumBlockNumber,
+		EthereumLogIndex:      ethereumLogIndex,
 	}
 }
 
 // OracleClaimContent is the details of how the content of the claim for each validator will be stored in the oracle
 type OracleClaimContent struct {
+	EthereumChainID      int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
 	CosmosReceiver       sdk.AccAddress  `json:"cosmos_receiver" yaml:"cosmos_receiver"`
-	Amount               int64           `json:"amount" yaml:"amount"`
+	Amount               sdk.Int         `json:"amount" yaml:"amount"`
 	Symbol               string          `json:"symbol" yaml:"symbol"`
 	TokenContractAddress EthereumAddress `json:"token_contract_address" yaml:"token_contract_address"`
 	ClaimType            ClaimType       `json:"claim_type" yaml:"claim_type"`
+	EthereumTxHash       EthereumTxHash  `json:"ethereum_tx_hash" yaml:"ethereum_tx_hash"`
+	EthereumBlockNumber  uint64          `json:"ethereum_block_number" yaml:"ethereum_block_number"`
+	EthereumLogIndex     uint64          `json:"ethereum_log_index" yaml:"ethereum_log_index"`
 }
 
 // NewOracleClaimContent is a constructor function for OracleClaim
 func NewOracleClaimContent(
-	cosmosReceiver sdk.AccAddress, amount int64, symbol string, tokenContractAddress EthereumAddress, claimType ClaimType,
+	ethereumChainID int, cosmosReceiver sdk.AccAddress, amount sdk.Int, symbol string,
+	tokenContractAddress EthereumAddress, claimType ClaimType,
+	ethereumTxHash EthereumTxHash, ethereumBlockNumber uint64, ethereumLogIndex uint64,
 ) OracleClaimContent {
 	return OracleClaimContent{
+		EthereumChainID:      ethereumChainID,
 		CosmosReceiver:       cosmosReceiver,
 		Amount:               amount,
 		Symbol:               symbol,
 		TokenContractAddress: tokenContractAddress,
 		ClaimType:            claimType,
+		EthereumTxHash:       ethereumTxHash,
+		EthereumBlockNumber:  ethereumBlockNumber,
+		EthereumLogIndex:     ethereumLogIndex,
 	}
 }
 
+// Validate checks that the claim content can be processed once its prophecy succeeds and that it locates the log
+// of its event on ethereum
+func (content OracleClaimContent) Validate() error {
+	if content.EthereumChainID <= 0 {
+		return sdkerrors.Wrapf(ErrInvalidEthereumChainID, "%d", content.EthereumChainID)
+	}
+	if content.CosmosReceiver.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing cosmos receiver")
+	}
+	if !IsPositiveAmount(content.Amount) {
+		return ErrInvalidAmount
+	}
+	if len(content.Symbol) == 0 {
+		return ErrInvalidSymbol
+	}
+	if content.EthereumTxHash.Empty() {
+		return ErrInvalidEthTxHash
+	}
+	if content.EthereumBlockNumber == 0 {
+		return ErrInvalidEthBlockNumber
+	}
+	return nil
+}
+
 // CreateOracleClaimFromEthClaim converts a specific ethereum bridge claim to a general oracle claim to be used by
 // the oracle module. The oracle module expects every claim for a particular prophecy to have the same id, so this id
 // must be created in a deterministic way that all validators can follow.
-// For this, we use the Nonce an Ethereum Sender provided,
+// For this, we use the ethereum chain id, bridge contract, nonce and ethereum sender provided,
 // as all validators will see this same data from the smart contract.
 func CreateOracleClaimFromEthClaim(cdc *codec.Codec, ethClaim EthBridgeClaim) (oracle.Claim, error) {
-	oracleID := strconv.Itoa(ethClaim.EthereumChainID) + strconv.Itoa(ethClaim.Nonce) + ethClaim.EthereumSender.String()
-	claimContent := NewOracleClaimContent(ethClaim.CosmosReceiver, ethClaim.Amount,
-		ethClaim.Symbol, ethClaim.TokenContractAddress, ethClaim.ClaimType)
+	oracleID := ProphecyID(ethClaim.EthereumChainID, ethClaim.BridgeContractAddress, ethClaim.Nonce,
+		ethClaim.EthereumSender)
+	claimContent := NewOracleClaimContent(ethClaim.EthereumChainID, ethClaim.CosmosReceiver, ethClaim.Amount,
+		ethClaim.Symbol, ethClaim.TokenContractAddress, ethClaim.ClaimType,
+		ethClaim.EthereumTxHash, ethClaim.EthereumBlockNumber, ethClaim.EthereumLogIndex)
 	claimBytes, err := json.Marshal(claimContent)
 	if err != nil {
 		return oracle.Claim{}, err
 	}
 	claimString := string(claimBytes)
-	claim := oracle.NewClaim(oracleID, ethClaim.ValidatorAddress, claimString)
+	claim := oracle.NewClaim(ModuleName, oracleID, ethClaim.ValidatorAddress, claimString)
 	return claim, nil
 }
 
+// ProphecyID returns the id of the oracle prophecy of the claims made on the event with the given nonce, sent by the
+// given ethereum sender through the given bridge contract on the given ethereum chain. Its fields are separated by
+// colons, which neither decimal numbers nor hex-encoded addresses contain, so distinct events never share an id.
+func ProphecyID(
+	ethereumChainID int, bridgeContract EthereumAddress, nonce int, ethereumSender EthereumAddress,
+) string {
+	return fmt.Sprintf("%d:%s:%d:%s", ethereumChainID, bridgeContract, nonce, ethereumSender)
+}
+
+// LegacyProphecyID returns the id prophecies had before their ids covered the bridge contract. It concatenates the
+// ethereum chain id, the nonce and the ethereum sender, so distinct events could share it.
+func LegacyProphecyID(ethereumChainID int, nonce int, ethereumSender EthereumAddress) string {
+	return strconv.Itoa(ethereumChainID) + strconv.Itoa(nonce) + ethereumSender.String()
+}
+
+// EthBridgeClaimCommitment returns the commitment a validator makes to the given claim with the given salt before
+// revealing it
+func EthBridgeClaimCommitment(cdc *codec.Codec, ethClaim EthBridgeClaim, salt string) ([]byte, error) {
+	oracleClaim, err := CreateOracleClaimFromEthClaim(cdc, ethClaim)
+	if err != nil {
+		return nil, err
+	}
+	return oracle.ClaimCommitment(salt, oracleClaim.ValidatorAddress, oracleClaim.Content), nil
+}
+
+// OracleClaimContentType is the oracle claim content type of the ethbridge namespace, its claims are JSON encoded
+// OracleClaimContents indexed by the hash of the ethereum transaction they were made on
+type OracleClaimContentType struct{}
+
+var _ oracle.IndexedClaimContentType = OracleClaimContentType{}
+
+// NormalizeContent implements the oracle.ClaimContentType interface. It rejects claims that could not be processed
+// once their prophecy succeeds, and re-encodes the others so that claims with the same content are tallied together
+// however they were encoded.
+func (OracleClaimContentType) NormalizeContent(content string) (string, error) {
+	oracleClaim, err := CreateOracleClaimFromOracleString(content)
+	if err != nil {
+		return "", err
+	}
+	if err := oracleClaim.Validate(); err != nil {
+		return "", err
+	}
+
+	bz, err := json.Marshal(oracleClaim)
+	if err != nil {
+		return "", sdkerrors.Wrap(ErrJSONMarshalling, err.Error())
+	}
+	return string(bz), nil
+}
+
+// ContentReferences implements the oracle.IndexedClaimContentType interface, claims are referenced by the hash of
+// the ethereum transaction they were made on
+func (OracleClaimContentType) ContentReferences(content string) []string {
+	oracleClaim, err := CreateOracleClaimFromOracleString(content)
+	if err != nil || oracleClaim.EthereumTxHash.Empty() {
+		return nil
+	}
+	return []string{oracleClaim.EthereumTxHash.String()}
+}
+
+// AggregationMode implements the oracle.ClaimContentType interface, a claim succeeds once enough validators made
+// the exact same claim
+func (OracleClaimContentType) AggregationMode() oracle.AggregationMode {
+	return oracle.ExactMatchAggregation
+}
+
 // CreateEthClaimFromOracleString converts a string
 // from any generic claim from the oracle module into an ethereum bridge specific claim.
 func CreateEthClaimFromOracleString(
@@ -106,6 +216,9 @@ func CreateEthClaimFromOracleString(
 		validator,
 		oracleClaim.Amount,
 		oracleClaim.ClaimType,
+		oracleClaim.EthereumTxHash,
+		oracleClaim.EthereumBlockNumber,
+		oracleClaim.EthereumLogIndex,
 	), nil
 }
 
@@ -4,9 +4,22 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 )
 
+// ModuleCdc is the codec for the module
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
+
 // RegisterCodec registers concrete types on the Amino codec
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgCreateEthBridgeClaim{}, "ethbridge/MsgCreateEthBridgeClaim", nil)
+	cdc.RegisterConcrete(MsgCommitEthBridgeClaim{}, "ethbridge/MsgCommitEthBridgeClaim", nil)
+	cdc.RegisterConcrete(MsgRevealEthBridgeClaim{}, "ethbridge/MsgRevealEthBridgeClaim", nil)
+	cdc.RegisterConcrete(MsgRejectEthBridgeClaim{}, "ethbridge/MsgRejectEthBridgeClaim", nil)
 	cdc.RegisterConcrete(MsgBurn{}, "ethbridge/MsgBurn", nil)
 	cdc.RegisterConcrete(MsgLock{}, "ethbridge/MsgLock", nil)
+	cdc.RegisterConcrete(ResolveProphecyProposal{}, "ethbridge/ResolveProphecyProposal", nil)
 }
@@ -1,8 +1,6 @@
 package types
 
 import (
-	"fmt"
-
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -16,7 +14,23 @@ var (
 	ErrInvalidClaimType       = sdkerrors.Register(ModuleName, 5, "invalid claim type provided")
 	ErrInvalidEthereumChainID = sdkerrors.Register(ModuleName, 6, "invalid ethereum chain id")
 	ErrInvalidAmount          = sdkerrors.Register(ModuleName, 7, "amount must be a valid integer > 0")
-	ErrInvalidSymbol          = sdkerrors.Register(ModuleName, 8, "symbol must be 1 character or more")
+	ErrInvalidSymbol          = sdkerrors.Register(ModuleName, 8, "symbol must be a valid denom")
 	ErrInvalidBurnSymbol      = sdkerrors.Register(ModuleName, 9,
-		fmt.Sprintf("symbol of token to burn must be in the form %v{ethereumSymbol}", PeggedCoinPrefix))
+		"symbol of token to burn must be in the form {peggedCoinPrefix}{ethereumSymbol}")
+	ErrInvalidBridgeContract = sdkerrors.Register(ModuleName, 10, "bridge contract is not accepted by the bridge")
+	ErrInvalidTokenSymbol    = sdkerrors.Register(ModuleName, 11,
+		"symbol does not match the registered token of the token contract")
+	ErrInvalidSalt      = sdkerrors.Register(ModuleName, 12, "salt must be 1 character or more")
+	ErrInvalidEthTxHash = sdkerrors.Register(ModuleName, 13,
+		"invalid ethereum transaction hash provided, must be a non-zero hex-encoded 32 byte hash")
+	ErrInvalidEthBlockNumber = sdkerrors.Register(ModuleName, 14, "invalid ethereum block number provided, must be > 0")
+	ErrUnregisteredToken     = sdkerrors.Register(ModuleName, 15,
+		"token contract is not registered in the token registry of the bridge")
+	ErrTokenDisabled      = sdkerrors.Register(ModuleName, 16, "registered token is disabled")
+	ErrInsufficientEscrow = sdkerrors.Register(ModuleName, 17,
+		"ethbridge escrow holds fewer coins than the burn claim returns")
+	ErrInsufficientPeggedCoins = sdkerrors.Register(ModuleName, 18,
+		"bridge minted fewer pegged coins than the burn destroys")
+	ErrAmbiguousProphecyID = sdkerrors.Register(ModuleName, 19,
+		"ethereum event may be the event of a legacy prophecy whose id could not be migrated")
 )
@@ -8,8 +8,6 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-const PeggedCoinPrefix = "peggy"
-
 // EthereumAddress defines a standard ethereum address
 type EthereumAddress gethCommon.Address
 
@@ -32,3 +30,37 @@ func (ethAddr EthereumAddress) MarshalJSON() ([]byte, error) {
 func (ethAddr *EthereumAddress) UnmarshalJSON(input []byte) error {
 	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(gethCommon.Address{}), input, ethAddr[:])
 }
+
+// EthereumTxHash defines the hash of an ethereum transaction
+type EthereumTxHash gethCommon.Hash
+
+// NewEthereumTxHash is a constructor function for EthereumTxHash
+func NewEthereumTxHash(hash string) EthereumTxHash {
+	return EthereumTxHash(gethCommon.HexToHash(hash))
+}
+
+// IsHexEthereumTxHash returns whether the given string is a 0x-prefixed hex-encoded ethereum transaction hash
+func IsHexEthereumTxHash(hash string) bool {
+	bz, err := hexutil.Decode(hash)
+	return err == nil && len(bz) == gethCommon.HashLength
+}
+
+// Empty returns whether the transaction hash is the zero hash
+func (txHash EthereumTxHash) Empty() bool {
+	return txHash == EthereumTxHash{}
+}
+
+// String returns the hex encoding of the transaction hash
+func (txHash EthereumTxHash) String() string {
+	return gethCommon.Hash(txHash).Hex()
+}
+
+// MarshalJSON marshals the ethereum transaction hash to JSON
+func (txHash EthereumTxHash) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("\"%v\"", txHash.String())), nil
+}
+
+// UnmarshalJSON unmarshals an ethereum transaction hash
+func (txHash *EthereumTxHash) UnmarshalJSON(input []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(gethCommon.Hash{}), input, txHash[:])
+}
@@ -3,6 +3,9 @@ package types
 // Ethbridge module event types
 var (
 	EventTypeCreateClaim    = "create_claim"
+	EventTypeCommitClaim    = "commit_claim"
+	EventTypeRejectClaim    = "reject_claim"
+	EventTypeResolveClaim   = "resolve_claim"
 	EventTypeProphecyStatus = "prophecy_status"
 	EventTypeBurn           = "burn"
 	EventTypeLock           = "lock"
@@ -13,12 +16,14 @@ var (
 	AttributeKeySymbol         = "symbol"
 	AttributeKeyCoins          = "coins"
 	AttributeKeyStatus         = "status"
+	AttributeKeyProphecyID     = "prophecy_id"
 	AttributeKeyClaimType      = "claim_type"
 
 	AttributeKeyEthereumChainID  = "ethereum_chain_id"
 	AttributeKeyTokenContract    = "token_contract_address"
 	AttributeKeyCosmosSender     = "cosmos_sender"
 	AttributeKeyEthereumReceiver = "ethereum_receiver"
+	AttributeKeyEthereumTxHash   = "ethereum_tx_hash"
 
 	AttributeValueCategory = ModuleName
 )
@@ -19,11 +19,18 @@ type SupplyKeeper interface {
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
 	MintCoins(ctx sdk.Context, name string, amt sdk.Coins) error
 	BurnCoins(ctx sdk.Context, name string, amt sdk.Coins) error
-	SetModuleAccount(sdk.Context, supplyexported.ModuleAccountI)
+	GetModuleAccount(ctx sdk.Context, moduleName string) supplyexported.ModuleAccountI
+	GetSupply(ctx sdk.Context) supplyexported.SupplyI
 }
 
 // OracleKeeper defines the expected oracle keeper
 type OracleKeeper interface {
 	ProcessClaim(ctx sdk.Context, claim oracle.Claim) (oracle.Status, error)
-	GetProphecy(ctx sdk.Context, id string) (oracle.Prophecy, bool)
+	ProcessCommit(ctx sdk.Context, commit oracle.ClaimCommit) (oracle.Status, error)
+	RevealClaim(ctx sdk.Context, claim oracle.Claim, salt string) (oracle.Status, error)
+	ProcessRejection(ctx sdk.Context, namespace, id string, validator sdk.ValAddress) (oracle.Status, error)
+	ResolveProphecy(ctx sdk.Context, namespace, id string, status oracle.Status) error
+	GetProphecy(ctx sdk.Context, namespace, id string) (oracle.Prophecy, bool)
+	GetPropheciesByReference(ctx sdk.Context, namespace, reference string) []oracle.Prophecy
+	MigrateStore(ctx sdk.Context, namespace string, migrateID func(id string) (string, bool)) int
 }
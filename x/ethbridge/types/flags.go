@@ -5,4 +5,12 @@ const (
 	FlagEthereumChainID string = "ethereum-chain-id"
 	// FlagTokenContractAddr flag for passing the token contract address field
 	FlagTokenContractAddr string = "token-contract-address"
+	// FlagSalt flag for passing the salt a claim is committed with
+	FlagSalt string = "salt"
+	// FlagEthereumTxHash flag for passing the hash of the ethereum transaction a claim is made on
+	FlagEthereumTxHash string = "ethereum-tx-hash"
+	// FlagEthereumBlockNumber flag for passing the number of the ethereum block a claim is made on
+	FlagEthereumBlockNumber string = "ethereum-block-number"
+	// FlagEthereumLogIndex flag for passing the index in its block of the ethereum log a claim is made on
+	FlagEthereumLogIndex string = "ethereum-log-index"
 )
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState defines the ethbridge module's genesis state
+type GenesisState struct {
+	Params Params `json:"params" yaml:"params"`
+	// LockedCoins are the coins locked in the bridge escrow, net of those returned by burn claims
+	LockedCoins sdk.Coins `json:"locked_coins" yaml:"locked_coins"`
+	// PeggedCoins are the pegged coins minted for lock claims, net of those burned
+	PeggedCoins sdk.Coins `json:"pegged_coins" yaml:"pegged_coins"`
+}
+
+// NewGenesisState creates a new GenesisState instance
+func NewGenesisState(params Params, lockedCoins, peggedCoins sdk.Coins) GenesisState {
+	return GenesisState{
+		Params:      params,
+		LockedCoins: lockedCoins,
+		PeggedCoins: peggedCoins,
+	}
+}
+
+// DefaultGenesisState returns the default ethbridge genesis state
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(DefaultParams(), sdk.Coins{}, sdk.Coins{})
+}
+
+// ValidateGenesis performs basic validation of the ethbridge genesis state
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	if !data.LockedCoins.IsValid() {
+		return fmt.Errorf("invalid locked coins: %s", data.LockedCoins)
+	}
+	if !data.PeggedCoins.IsValid() {
+		return fmt.Errorf("invalid pegged coins: %s", data.PeggedCoins)
+	}
+	return nil
+}
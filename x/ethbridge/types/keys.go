@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 const (
 	// ModuleName is the name of the ethereum bridge module
 	ModuleName = "ethbridge"
@@ -13,3 +15,21 @@ const (
 	// RouterKey is the msg router key for the ethereum bridge module
 	RouterKey = ModuleName
 )
+
+var (
+	// LockedCoinsKey is the key of the coins native to cosmos locked in the bridge escrow, net of those returned
+	LockedCoinsKey = []byte{0x01}
+
+	// PeggedCoinsKey is the key of the pegged coins minted for lock claims, net of those burned
+	PeggedCoinsKey = []byte{0x02}
+
+	// AmbiguousEventKeyPrefix is the prefix of the ethereum events a legacy prophecy that could not be moved to the
+	// id of a single event may be the prophecy of
+	AmbiguousEventKeyPrefix = []byte{0x03}
+)
+
+// AmbiguousEventKey returns the key recording that the ethereum event with the given chain id, nonce and sender may
+// be the event of a legacy prophecy, whatever its bridge contract. Its value is the legacy id of the prophecy.
+func AmbiguousEventKey(ethereumChainID int, nonce int, ethereumSender EthereumAddress) []byte {
+	return append(AmbiguousEventKeyPrefix, []byte(fmt.Sprintf("%d:%d:%s", ethereumChainID, nonce, ethereumSender))...)
+}
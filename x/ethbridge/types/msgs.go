@@ -2,7 +2,6 @@ package types
 
 import (
 	"encoding/json"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -10,12 +9,14 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/sifchain/peggy/x/oracle"
 )
 
 // MsgLock defines a message for locking coins and triggering a related event
 type MsgLock struct {
 	CosmosSender     sdk.AccAddress  `json:"cosmos_sender" yaml:"cosmos_sender"`
-	Amount           int64           `json:"amount" yaml:"amount"`
+	Amount           sdk.Int         `json:"amount" yaml:"amount"`
 	Symbol           string          `json:"symbol" yaml:"symbol"`
 	EthereumChainID  int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
 	EthereumReceiver EthereumAddress `json:"ethereum_receiver" yaml:"ethereum_receiver"`
@@ -24,7 +25,7 @@ type MsgLock struct {
 // NewMsgLock is a constructor function for MsgLock
 func NewMsgLock(
 	ethereumChainID int, cosmosSender sdk.AccAddress,
-	ethereumReceiver EthereumAddress, amount int64, symbol string) MsgLock {
+	ethereumReceiver EthereumAddress, amount sdk.Int, symbol string) MsgLock {
 	return MsgLock{
 		EthereumChainID:  ethereumChainID,
 		CosmosSender:     cosmosSender,
@@ -58,12 +59,12 @@ func (msg MsgLock) ValidateBasic() error {
 		return ErrInvalidEthAddress
 	}
 
-	if msg.Amount <= 0 {
+	if !IsPositiveAmount(msg.Amount) {
 		return ErrInvalidAmount
 	}
 
-	if len(msg.Symbol) == 0 {
-		return ErrInvalidSymbol
+	if err := sdk.ValidateDenom(msg.Symbol); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSymbol, err.Error())
 	}
 
 	return nil
@@ -87,7 +88,7 @@ func (msg MsgLock) GetSigners() []sdk.AccAddress {
 // MsgBurn defines a message for burning coins and triggering a related event
 type MsgBurn struct {
 	CosmosSender     sdk.AccAddress  `json:"cosmos_sender" yaml:"cosmos_sender"`
-	Amount           int64           `json:"amount" yaml:"amount"`
+	Amount           sdk.Int         `json:"amount" yaml:"amount"`
 	Symbol           string          `json:"symbol" yaml:"symbol"`
 	EthereumChainID  int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
 	EthereumReceiver EthereumAddress `json:"ethereum_receiver" yaml:"ethereum_receiver"`
@@ -96,7 +97,7 @@ type MsgBurn struct {
 // NewMsgBurn is a constructor function for MsgBurn
 func NewMsgBurn(
 	ethereumChainID int, cosmosSender sdk.AccAddress,
-	ethereumReceiver EthereumAddress, amount int64, symbol string) MsgBurn {
+	ethereumReceiver EthereumAddress, amount sdk.Int, symbol string) MsgBurn {
 	return MsgBurn{
 		EthereumChainID:  ethereumChainID,
 		CosmosSender:     cosmosSender,
@@ -126,20 +127,11 @@ func (msg MsgBurn) ValidateBasic() error {
 	if !gethCommon.IsHexAddress(msg.EthereumReceiver.String()) {
 		return ErrInvalidEthAddress
 	}
-	if msg.Amount <= 0 {
+	if !IsPositiveAmount(msg.Amount) {
 		return ErrInvalidAmount
 	}
-	prefixLength := len(PeggedCoinPrefix)
-	if len(msg.Symbol) <= prefixLength+1 {
-		return ErrInvalidBurnSymbol
-	}
-	symbolPrefix := msg.Symbol[:prefixLength]
-	if symbolPrefix != PeggedCoinPrefix {
-		return ErrInvalidBurnSymbol
-	}
-	symbolSuffix := msg.Symbol[prefixLength:]
-	if len(symbolSuffix) == 0 {
-		return ErrInvalidBurnSymbol
+	if err := sdk.ValidateDenom(msg.Symbol); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSymbol, err.Error())
 	}
 	return nil
 }
@@ -187,6 +179,17 @@ func (msg MsgCreateEthBridgeClaim) ValidateBasic() error {
 		return ErrInvalidEthNonce
 	}
 
+	if !IsPositiveAmount(msg.Amount) {
+		return ErrInvalidAmount
+	}
+
+	if msg.EthereumTxHash.Empty() {
+		return ErrInvalidEthTxHash
+	}
+	if msg.EthereumBlockNumber == 0 {
+		return ErrInvalidEthBlockNumber
+	}
+
 	if !gethCommon.IsHexAddress(msg.EthereumSender.String()) {
 		return ErrInvalidEthAddress
 	}
@@ -217,28 +220,201 @@ func (msg MsgCreateEthBridgeClaim) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddress)}
 }
 
+// MsgCommitEthBridgeClaim defines a message for committing to a claim on the ethereum bridge before revealing it
+type MsgCommitEthBridgeClaim struct {
+	EthereumChainID       int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
+	BridgeContractAddress EthereumAddress `json:"bridge_registry_contract_address" yaml:"bridge_registry_contract_address"`
+	Nonce                 int             `json:"nonce" yaml:"nonce"`
+	EthereumSender        EthereumAddress `json:"ethereum_sender" yaml:"ethereum_sender"`
+	ValidatorAddress      sdk.ValAddress  `json:"validator_address" yaml:"validator_address"`
+	Commitment            []byte          `json:"commitment" yaml:"commitment"`
+}
+
+// NewMsgCommitEthBridgeClaim is a constructor function for MsgCommitEthBridgeClaim
+func NewMsgCommitEthBridgeClaim(
+	ethereumChainID int, bridgeContract EthereumAddress, nonce int, ethereumSender EthereumAddress,
+	validator sdk.ValAddress, commitment []byte,
+) MsgCommitEthBridgeClaim {
+	return MsgCommitEthBridgeClaim{
+		EthereumChainID:       ethereumChainID,
+		BridgeContractAddress: bridgeContract,
+		Nonce:                 nonce,
+		EthereumSender:        ethereumSender,
+		ValidatorAddress:      validator,
+		Commitment:            commitment,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCommitEthBridgeClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitEthBridgeClaim) Type() string { return "commit_bridge_claim" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCommitEthBridgeClaim) ValidateBasic() error {
+	if msg.ValidatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.ValidatorAddress.String())
+	}
+
+	if msg.Nonce < 0 {
+		return ErrInvalidEthNonce
+	}
+
+	if !gethCommon.IsHexAddress(msg.EthereumSender.String()) {
+		return ErrInvalidEthAddress
+	}
+	if !gethCommon.IsHexAddress(msg.BridgeContractAddress.String()) {
+		return ErrInvalidEthAddress
+	}
+	if len(msg.Commitment) != oracle.CommitmentLength {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "commitment must be %d bytes long",
+			oracle.CommitmentLength)
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCommitEthBridgeClaim) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCommitEthBridgeClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddress)}
+}
+
+// MsgRevealEthBridgeClaim defines a message for revealing a claim committed to on the ethereum bridge
+type MsgRevealEthBridgeClaim struct {
+	EthBridgeClaim EthBridgeClaim `json:"eth_bridge_claim" yaml:"eth_bridge_claim"`
+	Salt           string         `json:"salt" yaml:"salt"`
+}
+
+// NewMsgRevealEthBridgeClaim is a constructor function for MsgRevealEthBridgeClaim
+func NewMsgRevealEthBridgeClaim(ethBridgeClaim EthBridgeClaim, salt string) MsgRevealEthBridgeClaim {
+	return MsgRevealEthBridgeClaim{
+		EthBridgeClaim: ethBridgeClaim,
+		Salt:           salt,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevealEthBridgeClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevealEthBridgeClaim) Type() string { return "reveal_bridge_claim" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevealEthBridgeClaim) ValidateBasic() error {
+	if len(msg.Salt) == 0 {
+		return ErrInvalidSalt
+	}
+	return NewMsgCreateEthBridgeClaim(msg.EthBridgeClaim).ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevealEthBridgeClaim) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevealEthBridgeClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.EthBridgeClaim.ValidatorAddress)}
+}
+
+// MsgRejectEthBridgeClaim defines a message for voting to reject the prophecy of an ethereum event
+type MsgRejectEthBridgeClaim struct {
+	EthereumChainID       int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
+	BridgeContractAddress EthereumAddress `json:"bridge_registry_contract_address" yaml:"bridge_registry_contract_address"`
+	Nonce                 int             `json:"nonce" yaml:"nonce"`
+	EthereumSender        EthereumAddress `json:"ethereum_sender" yaml:"ethereum_sender"`
+	ValidatorAddress      sdk.ValAddress  `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewMsgRejectEthBridgeClaim is a constructor function for MsgRejectEthBridgeClaim
+func NewMsgRejectEthBridgeClaim(
+	ethereumChainID int, bridgeContract EthereumAddress, nonce int, ethereumSender EthereumAddress,
+	validator sdk.ValAddress,
+) MsgRejectEthBridgeClaim {
+	return MsgRejectEthBridgeClaim{
+		EthereumChainID:       ethereumChainID,
+		BridgeContractAddress: bridgeContract,
+		Nonce:                 nonce,
+		EthereumSender:        ethereumSender,
+		ValidatorAddress:      validator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRejectEthBridgeClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRejectEthBridgeClaim) Type() string { return "reject_bridge_claim" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRejectEthBridgeClaim) ValidateBasic() error {
+	if msg.ValidatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.ValidatorAddress.String())
+	}
+
+	if msg.Nonce < 0 {
+		return ErrInvalidEthNonce
+	}
+
+	if !gethCommon.IsHexAddress(msg.EthereumSender.String()) {
+		return ErrInvalidEthAddress
+	}
+	if !gethCommon.IsHexAddress(msg.BridgeContractAddress.String()) {
+		return ErrInvalidEthAddress
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRejectEthBridgeClaim) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRejectEthBridgeClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddress)}
+}
+
 // MapOracleClaimsToEthBridgeClaims maps a set of generic oracle claim data into EthBridgeClaim objects
 func MapOracleClaimsToEthBridgeClaims(
 	ethereumChainID int, bridgeContract EthereumAddress, nonce int, symbol string,
 	tokenContract EthereumAddress, ethereumSender EthereumAddress,
-	oracleValidatorClaims map[string]string,
+	oracleValidatorClaims []oracle.ValidatorClaim,
 	f func(int, EthereumAddress, int, EthereumAddress, sdk.ValAddress, string,
 	) (EthBridgeClaim, error),
 ) ([]EthBridgeClaim, error) {
 	mappedClaims := make([]EthBridgeClaim, len(oracleValidatorClaims))
-	i := 0
-	for validatorBech32, validatorClaim := range oracleValidatorClaims {
-		validatorAddress, parseErr := sdk.ValAddressFromBech32(validatorBech32)
-		if parseErr != nil {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, fmt.Sprintf("failed to parse claim: %s", parseErr))
-		}
+	for i, validatorClaim := range oracleValidatorClaims {
 		mappedClaim, err := f(
-			ethereumChainID, bridgeContract, nonce, ethereumSender, validatorAddress, validatorClaim)
+			ethereumChainID, bridgeContract, nonce, ethereumSender, validatorClaim.Validator, validatorClaim.Content)
 		if err != nil {
 			return nil, err
 		}
 		mappedClaims[i] = mappedClaim
-		i++
 	}
 	return mappedClaims, nil
 }
+
+// IsPositiveAmount returns true if the given amount is set and greater than zero. Amounts are sdk.Ints, which hold at
+// most 255 bits: ERC20 amounts of 2^255 and more fail to decode rather than overflow, so they cannot be bridged.
+// Amounts are nil when missing from a decoded message.
+func IsPositiveAmount(amount sdk.Int) bool {
+	return amount != (sdk.Int{}) && amount.IsPositive()
+}
@@ -0,0 +1,286 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default ethbridge module parameter subspace
+const DefaultParamspace = ModuleName
+
+// DefaultPeggedCoinPrefix defines the default prefix of the denom of coins minted for assets locked on Ethereum
+const DefaultPeggedCoinPrefix = "peggy"
+
+// Parameter store keys
+var (
+	KeyEthereumChainIDs        = []byte("EthereumChainIDs")
+	KeyBridgeContractAddresses = []byte("BridgeContractAddresses")
+	KeyPeggedCoinPrefix        = []byte("PeggedCoinPrefix")
+	KeyTokenRegistry           = []byte("TokenRegistry")
+)
+
+var _ params.ParamSet = (*Params)(nil)
+
+// RegisteredToken is the entry of a token contract on an Ethereum chain in the token registry. Claims about the token
+// must carry its symbol, and the coins bridged for it have its denom whatever symbol the claims carry. The denom
+// decides which side the token is native to: the keeper treats tokens whose denom has the pegged coin prefix as native
+// to Ethereum, only bridged by lock claims, and the others as native to Cosmos, only bridged back by burn claims.
+type RegisteredToken struct {
+	EthereumChainID      int             `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
+	TokenContractAddress EthereumAddress `json:"token_contract_address" yaml:"token_contract_address"`
+	Symbol               string          `json:"symbol" yaml:"symbol"`
+	Denom                string          `json:"denom" yaml:"denom"`
+	Enabled              bool            `json:"enabled" yaml:"enabled"`
+}
+
+// NewRegisteredToken creates a new RegisteredToken instance
+func NewRegisteredToken(
+	ethereumChainID int, tokenContractAddress EthereumAddress, symbol string, denom string, enabled bool,
+) RegisteredToken {
+	return RegisteredToken{
+		EthereumChainID:      ethereumChainID,
+		TokenContractAddress: tokenContractAddress,
+		Symbol:               symbol,
+		Denom:                denom,
+		Enabled:              enabled,
+	}
+}
+
+// String implements the fmt.Stringer interface
+func (rt RegisteredToken) String() string {
+	status := "enabled"
+	if !rt.Enabled {
+		status = "disabled"
+	}
+	return fmt.Sprintf("%d/%s: %s as %s (%s)",
+		rt.EthereumChainID, rt.TokenContractAddress, rt.Symbol, rt.Denom, status)
+}
+
+// Params defines the parameters for the ethbridge module
+type Params struct {
+	// Ethereum chain ids claims, locks and burns may refer to; empty accepts any chain id
+	EthereumChainIDs []int `json:"ethereum_chain_ids" yaml:"ethereum_chain_ids"`
+	// Bridge contracts claims may originate from; empty accepts any bridge contract
+	BridgeContractAddresses []EthereumAddress `json:"bridge_contract_addresses" yaml:"bridge_contract_addresses"`
+	// Prefix of the denom of coins minted for assets locked on Ethereum
+	PeggedCoinPrefix string `json:"pegged_coin_prefix" yaml:"pegged_coin_prefix"`
+	// Token contracts claims may be about, with the denom of the coins bridged for them
+	TokenRegistry []RegisteredToken `json:"token_registry" yaml:"token_registry"`
+}
+
+// ParamKeyTable returns the key declaration for the ethbridge module parameters
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params instance
+func NewParams(
+	ethereumChainIDs []int, bridgeContractAddresses []EthereumAddress, peggedCoinPrefix string,
+	tokenRegistry []RegisteredToken,
+) Params {
+	return Params{
+		EthereumChainIDs:        ethereumChainIDs,
+		BridgeContractAddresses: bridgeContractAddresses,
+		PeggedCoinPrefix:        peggedCoinPrefix,
+		TokenRegistry:           tokenRegistry,
+	}
+}
+
+// DefaultParams returns the default parameters for the ethbridge module
+func DefaultParams() Params {
+	return NewParams([]int{}, []EthereumAddress{}, DefaultPeggedCoinPrefix, []RegisteredToken{})
+}
+
+// ParamSetPairs implements the params.ParamSet interface
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyEthereumChainIDs, &p.EthereumChainIDs, validateEthereumChainIDs),
+		params.NewParamSetPair(KeyBridgeContractAddresses, &p.BridgeContractAddresses, validateBridgeContractAddresses),
+		params.NewParamSetPair(KeyPeggedCoinPrefix, &p.PeggedCoinPrefix, validatePeggedCoinPrefix),
+		params.NewParamSetPair(KeyTokenRegistry, &p.TokenRegistry, validateTokenRegistry),
+	}
+}
+
+// Validate performs basic validation of the ethbridge parameters
+func (p Params) Validate() error {
+	if err := validateEthereumChainIDs(p.EthereumChainIDs); err != nil {
+		return err
+	}
+	if err := validateBridgeContractAddresses(p.BridgeContractAddresses); err != nil {
+		return err
+	}
+	if err := validatePeggedCoinPrefix(p.PeggedCoinPrefix); err != nil {
+		return err
+	}
+	if err := validateTokenRegistry(p.TokenRegistry); err != nil {
+		return err
+	}
+
+	for _, token := range p.TokenRegistry {
+		if !p.IsAcceptedEthereumChainID(token.EthereumChainID) {
+			return fmt.Errorf("registered token %s refers to an unaccepted ethereum chain id", token)
+		}
+	}
+
+	return nil
+}
+
+// IsAcceptedEthereumChainID returns true if the given ethereum chain id is accepted by the bridge
+func (p Params) IsAcceptedEthereumChainID(ethereumChainID int) bool {
+	if len(p.EthereumChainIDs) == 0 {
+		return true
+	}
+	for _, id := range p.EthereumChainIDs {
+		if id == ethereumChainID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAcceptedBridgeContract returns true if claims from the given bridge contract are accepted by the bridge
+func (p Params) IsAcceptedBridgeContract(bridgeContract EthereumAddress) bool {
+	if len(p.BridgeContractAddresses) == 0 {
+		return true
+	}
+	for _, address := range p.BridgeContractAddresses {
+		if address == bridgeContract {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRegisteredToken returns the registry entry of the given token contract on the given ethereum chain, if any
+func (p Params) GetRegisteredToken(ethereumChainID int, tokenContract EthereumAddress) (RegisteredToken, bool) {
+	for _, token := range p.TokenRegistry {
+		if token.EthereumChainID == ethereumChainID && token.TokenContractAddress == tokenContract {
+			return token, true
+		}
+	}
+	return RegisteredToken{}, false
+}
+
+// IsPeggedDenom returns true if the given denom is the denom of coins minted for assets locked on Ethereum
+func (p Params) IsPeggedDenom(denom string) bool {
+	return len(denom) > len(p.PeggedCoinPrefix) && strings.HasPrefix(denom, p.PeggedCoinPrefix)
+}
+
+// String implements the fmt.Stringer interface
+func (p Params) String() string {
+	chainIDs := make([]string, len(p.EthereumChainIDs))
+	for i, id := range p.EthereumChainIDs {
+		chainIDs[i] = fmt.Sprint(id)
+	}
+	bridgeContracts := make([]string, len(p.BridgeContractAddresses))
+	for i, address := range p.BridgeContractAddresses {
+		bridgeContracts[i] = address.String()
+	}
+	tokenRegistry := make([]string, len(p.TokenRegistry))
+	for i, token := range p.TokenRegistry {
+		tokenRegistry[i] = token.String()
+	}
+
+	return fmt.Sprintf(`EthBridge Params:
+  Ethereum Chain IDs:        %s
+  Bridge Contract Addresses: %s
+  Pegged Coin Prefix:        %s
+  Token Registry:            %s`,
+		strings.Join(chainIDs, ", "), strings.Join(bridgeContracts, ", "),
+		p.PeggedCoinPrefix, strings.Join(tokenRegistry, ", "))
+}
+
+func validateEthereumChainIDs(i interface{}) error {
+	v, ok := i.([]int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[int]bool)
+	for _, id := range v {
+		if id <= 0 {
+			return sdkerrors.Wrapf(ErrInvalidEthereumChainID, "%d", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate ethereum chain id %d", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+func validateBridgeContractAddresses(i interface{}) error {
+	v, ok := i.([]EthereumAddress)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[EthereumAddress]bool)
+	for _, address := range v {
+		if address == (EthereumAddress{}) {
+			return sdkerrors.Wrap(ErrInvalidEthAddress, "bridge contract cannot be the null address")
+		}
+		if seen[address] {
+			return fmt.Errorf("duplicate bridge contract address %s", address)
+		}
+		seen[address] = true
+	}
+
+	return nil
+}
+
+func validatePeggedCoinPrefix(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := sdk.ValidateDenom(v); err != nil {
+		return fmt.Errorf("invalid pegged coin prefix: %s", err)
+	}
+
+	return nil
+}
+
+func validateTokenRegistry(i interface{}) error {
+	v, ok := i.([]RegisteredToken)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	type tokenKey struct {
+		ethereumChainID int
+		tokenContract   EthereumAddress
+	}
+	seen := make(map[tokenKey]bool)
+	denoms := make(map[string]bool)
+	for _, token := range v {
+		if token.EthereumChainID <= 0 {
+			return sdkerrors.Wrapf(ErrInvalidEthereumChainID, "%d", token.EthereumChainID)
+		}
+		if len(token.Symbol) == 0 {
+			return ErrInvalidSymbol
+		}
+		if err := sdk.ValidateDenom(token.Denom); err != nil {
+			return fmt.Errorf("invalid denom of registered token %s: %s", token, err)
+		}
+
+		key := tokenKey{token.EthereumChainID, token.TokenContractAddress}
+		if seen[key] {
+			return fmt.Errorf("duplicate registered token %s", token)
+		}
+		seen[key] = true
+		// distinct token contracts never mint or release the same coins
+		if denoms[token.Denom] {
+			return fmt.Errorf("denom %s is registered for several tokens", token.Denom)
+		}
+		denoms[token.Denom] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	gethCommon "github.com/ethereum/go-ethereum/common"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeResolveProphecy defines the type for a ResolveProphecyProposal
+	ProposalTypeResolveProphecy = "ResolveProphecy"
+)
+
+// Assert ResolveProphecyProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = ResolveProphecyProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeResolveProphecy)
+	govtypes.RegisterProposalTypeCodec(ResolveProphecyProposal{}, "ethbridge/ResolveProphecyProposal")
+}
+
+// ResolveProphecyProposal resolves the prophecy of an ethereum event that is stuck or wrong. The prophecy is
+// failed if the proposal has no final claim. Otherwise the final claim is processed as if validators had reached
+// consensus on it, which mints or unlocks its coins.
+type ResolveProphecyProposal struct {
+	Title                 string              `json:"title" yaml:"title"`
+	Description           string              `json:"description" yaml:"description"`
+	EthereumChainID       int                 `json:"ethereum_chain_id" yaml:"ethereum_chain_id"`
+	BridgeContractAddress EthereumAddress     `json:"bridge_registry_contract_address" yaml:"bridge_registry_contract_address"` //nolint:lll
+	Nonce                 int                 `json:"nonce" yaml:"nonce"`
+	EthereumSender        EthereumAddress     `json:"ethereum_sender" yaml:"ethereum_sender"`
+	FinalClaim            *OracleClaimContent `json:"final_claim,omitempty" yaml:"final_claim,omitempty"`
+}
+
+// NewResolveProphecyProposal creates a new proposal to resolve the prophecy of an ethereum event, with the given
+// final claim or nil to fail it
+func NewResolveProphecyProposal(
+	title, description string, ethereumChainID int, bridgeContract EthereumAddress, nonce int,
+	ethereumSender EthereumAddress, finalClaim *OracleClaimContent,
+) ResolveProphecyProposal {
+	return ResolveProphecyProposal{
+		Title:                 title,
+		Description:           description,
+		EthereumChainID:       ethereumChainID,
+		BridgeContractAddress: bridgeContract,
+		Nonce:                 nonce,
+		EthereumSender:        ethereumSender,
+		FinalClaim:            finalClaim,
+	}
+}
+
+// GetTitle returns the title of a resolve prophecy proposal.
+func (p ResolveProphecyProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of a resolve prophecy proposal.
+func (p ResolveProphecyProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of a resolve prophecy proposal.
+func (p ResolveProphecyProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a resolve prophecy proposal.
+func (p ResolveProphecyProposal) ProposalType() string { return ProposalTypeResolveProphecy }
+
+// ValidateBasic runs basic stateless validity checks
+func (p ResolveProphecyProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if p.Nonce < 0 {
+		return ErrInvalidEthNonce
+	}
+	if !gethCommon.IsHexAddress(p.EthereumSender.String()) {
+		return ErrInvalidEthAddress
+	}
+	if !gethCommon.IsHexAddress(p.BridgeContractAddress.String()) {
+		return ErrInvalidEthAddress
+	}
+	if p.FinalClaim == nil {
+		return nil
+	}
+
+	if err := p.FinalClaim.Validate(); err != nil {
+		return err
+	}
+	if p.FinalClaim.EthereumChainID != p.EthereumChainID {
+		return sdkerrors.Wrapf(ErrInvalidEthereumChainID, "final claim is about ethereum chain %d, not %d",
+			p.FinalClaim.EthereumChainID, p.EthereumChainID)
+	}
+	if !gethCommon.IsHexAddress(p.FinalClaim.TokenContractAddress.String()) {
+		return ErrInvalidEthAddress
+	}
+	if strings.ToLower(p.FinalClaim.Symbol) == "eth" &&
+		p.FinalClaim.TokenContractAddress != NewEthereumAddress("0x0000000000000000000000000000000000000000") {
+		return ErrInvalidEthSymbol
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p ResolveProphecyProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Resolve Prophecy Proposal:
+  Title:             %s
+  Description:       %s
+  Ethereum Chain ID: %d
+  Bridge Contract:   %s
+  Nonce:             %d
+  Ethereum Sender:   %s
+`, p.Title, p.Description, p.EthereumChainID, p.BridgeContractAddress, p.Nonce, p.EthereumSender))
+	if p.FinalClaim == nil {
+		b.WriteString("  Final Claim:       none, the prophecy fails\n")
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf(`  Final Claim:
+    Cosmos Receiver: %s
+    Amount:          %s
+    Symbol:          %s
+    Token Contract:  %s
+    Claim Type:      %s
+    Tx Hash:         %s
+    Block Number:    %d
+    Log Index:       %d
+`, p.FinalClaim.CosmosReceiver, p.FinalClaim.Amount, p.FinalClaim.Symbol, p.FinalClaim.TokenContractAddress,
+		p.FinalClaim.ClaimType, p.FinalClaim.EthereumTxHash, p.FinalClaim.EthereumBlockNumber,
+		p.FinalClaim.EthereumLogIndex))
+	return b.String()
+}
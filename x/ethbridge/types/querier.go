@@ -9,7 +9,8 @@ import (
 
 // query endpoints supported by the oracle Querier
 const (
-	QueryEthProphecy = "prophecies"
+	QueryEthProphecy           = "prophecies"
+	QueryEthPropheciesByTxHash = "prophecies_by_tx_hash"
 )
 
 // QueryEthProphecyParams defines the params for the following queries:
@@ -38,6 +39,19 @@ func NewQueryEthProphecyParams(
 	}
 }
 
+// QueryEthPropheciesByTxHashParams defines the params for the following queries:
+// - 'custom/ethbridge/prophecies_by_tx_hash/'
+type QueryEthPropheciesByTxHashParams struct {
+	EthereumTxHash EthereumTxHash `json:"ethereum_tx_hash"`
+}
+
+// NewQueryEthPropheciesByTxHashParams creates a new QueryEthPropheciesByTxHashParams
+func NewQueryEthPropheciesByTxHashParams(ethereumTxHash EthereumTxHash) QueryEthPropheciesByTxHashParams {
+	return QueryEthPropheciesByTxHashParams{
+		EthereumTxHash: ethereumTxHash,
+	}
+}
+
 // QueryEthProphecyResponse defines the result payload for an eth prophecy query
 type QueryEthProphecyResponse struct {
 	ID     string           `json:"id"`
@@ -65,3 +79,30 @@ func (response QueryEthProphecyResponse) String() string {
 
 	return string(prophecyJSON)
 }
+
+// QueryEthPropheciesByTxHashResponse defines the result payload for a query of the prophecies claimed on an ethereum
+// transaction
+type QueryEthPropheciesByTxHashResponse struct {
+	EthereumTxHash EthereumTxHash    `json:"ethereum_tx_hash"`
+	Prophecies     []oracle.Prophecy `json:"prophecies"`
+}
+
+// NewQueryEthPropheciesByTxHashResponse creates a new QueryEthPropheciesByTxHashResponse instance
+func NewQueryEthPropheciesByTxHashResponse(
+	ethereumTxHash EthereumTxHash, prophecies []oracle.Prophecy,
+) QueryEthPropheciesByTxHashResponse {
+	return QueryEthPropheciesByTxHashResponse{
+		EthereumTxHash: ethereumTxHash,
+		Prophecies:     prophecies,
+	}
+}
+
+// String implements fmt.Stringer interface
+func (response QueryEthPropheciesByTxHashResponse) String() string {
+	prophecyJSON, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Sprintf("Error marshalling json: %v", err)
+	}
+
+	return string(prophecyJSON)
+}
@@ -25,8 +25,23 @@ const (
 	TestCoinsLockedSymbol     = "peggyeth"
 	AltTestCoinsAmount        = 12
 	AltTestCoinsSymbol        = "eth"
+	TestEthereumTxHash        = "0x9e6ba5ba4e5d3ee1b1c6c2aaae4ae7bba6cf50fa5c17ec1e9caea5bbc81de1f2"
+	TestEthereumBlockNumber   = 12
+	TestEthereumLogIndex      = 1
 )
 
+// CreateTestParams returns the default parameters with the test token registered
+func CreateTestParams() Params {
+	params := DefaultParams()
+	params.TokenRegistry = []RegisteredToken{CreateTestRegisteredToken()}
+	return params
+}
+
+func CreateTestRegisteredToken() RegisteredToken {
+	return NewRegisteredToken(TestEthereumChainID, NewEthereumAddress(TestTokenContractAddress), TestCoinsSymbol,
+		TestCoinsLockedSymbol, true)
+}
+
 //Ethereum-bridge specific stuff
 func CreateTestEthMsg(t *testing.T, validatorAddress sdk.ValAddress, claimType ClaimType) MsgCreateEthBridgeClaim {
 	testEthereumAddress := NewEthereumAddress(TestEthereumAddress)
@@ -47,7 +62,8 @@ func CreateTestEthClaim(
 	require.NoError(t, err1)
 	ethClaim := NewEthBridgeClaim(
 		TestEthereumChainID, testContractAddress, TestNonce, symbol,
-		testTokenAddress, testEthereumAddress, testCosmosAddress, validatorAddress, amount, claimType)
+		testTokenAddress, testEthereumAddress, testCosmosAddress, validatorAddress, sdk.NewInt(amount), claimType,
+		NewEthereumTxHash(TestEthereumTxHash), TestEthereumBlockNumber, TestEthereumLogIndex)
 	return ethClaim
 }
 
@@ -55,7 +71,7 @@ func CreateTestBurnMsg(t *testing.T, testCosmosSender string, ethereumReceiver E
 	coinsAmount int64, coinsSymbol string) MsgBurn {
 	testCosmosAddress, err := sdk.AccAddressFromBech32(TestAddress)
 	require.NoError(t, err)
-	burnEth := NewMsgBurn(TestEthereumChainID, testCosmosAddress, ethereumReceiver, coinsAmount, coinsSymbol)
+	burnEth := NewMsgBurn(TestEthereumChainID, testCosmosAddress, ethereumReceiver, sdk.NewInt(coinsAmount), coinsSymbol)
 	return burnEth
 }
 
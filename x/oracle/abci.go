@@ -0,0 +1,38 @@
+package oracle
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker re-tallies the pending prophecies after validator set changes and expires the prophecies that stayed
+// pending for too long. The claims of the prophecies that succeed are processed by the oracle hooks. The prophecies
+// finalized so far are then counted in the claim windows of their validators.
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	for _, prophecy := range keeper.RetallyPendingProphecies(ctx) {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeProphecyRetallied,
+				sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+				sdk.NewAttribute(AttributeKeyNamespace, prophecy.Namespace),
+				sdk.NewAttribute(AttributeKeyProphecyID, prophecy.ID),
+				sdk.NewAttribute(AttributeKeyStatus, prophecy.Status.Text.String()),
+			),
+		)
+	}
+
+	for _, prophecy := range keeper.ExpireProphecies(ctx) {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeProphecyExpired,
+				sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+				sdk.NewAttribute(AttributeKeyNamespace, prophecy.Namespace),
+				sdk.NewAttribute(AttributeKeyProphecyID, prophecy.ID),
+				sdk.NewAttribute(AttributeKeyCreationHeight, strconv.FormatInt(prophecy.CreationHeight, 10)),
+			),
+		)
+	}
+
+	keeper.ProcessLivenessQueue(ctx, keeper.GetParams(ctx).MaxLivenessUpdatesPerBlock)
+}
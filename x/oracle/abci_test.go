@@ -0,0 +1,40 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/keeper"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestEndBlockerExpiresProphecies(t *testing.T) {
+	ctx, oracleKeeper, _, _, _, validatorAddresses := keeper.CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	params := oracleKeeper.GetParams(ctx)
+	params.ProphecyExpiryBlocks = 5
+	oracleKeeper.SetParams(ctx, params)
+
+	ctx = ctx.WithBlockHeight(2)
+	_, err := oracleKeeper.ProcessClaim(ctx,
+		types.NewClaim(keeper.TestNamespace, keeper.TestID, validatorAddresses[0], keeper.TestString))
+	require.NoError(t, err)
+
+	ctx = ctx.WithBlockHeight(7).WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, oracleKeeper)
+
+	prophecy, found := oracleKeeper.GetProphecy(ctx, keeper.TestNamespace, keeper.TestID)
+	require.True(t, found)
+	require.Equal(t, ExpiredStatusText, prophecy.Status.Text)
+
+	// the validator that did not claim on the expired prophecy missed it
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	require.Equal(t, EventTypeProphecyExpired, events[0].Type)
+	require.Contains(t, events[0].Attributes, sdk.NewAttribute(AttributeKeyProphecyID, keeper.TestID).ToKVPair())
+	require.Contains(t, events[0].Attributes, sdk.NewAttribute(AttributeKeyCreationHeight, "2").ToKVPair())
+	require.Equal(t, EventTypeLiveness, events[1].Type)
+	require.Contains(t, events[1].Attributes,
+		sdk.NewAttribute(AttributeKeyValidator, validatorAddresses[1].String()).ToKVPair())
+}
@@ -12,23 +12,72 @@ import (
 )
 
 const (
-	DefaultConsensusNeeded = types.DefaultConsensusNeeded
-	ModuleName             = types.ModuleName
-	StoreKey               = types.StoreKey
-	QuerierRoute           = types.QuerierRoute
-	RouterKey              = types.RouterKey
-	PendingStatusText      = types.PendingStatusText
-	SuccessStatusText      = types.SuccessStatusText
-	FailedStatusText       = types.FailedStatusText
+	ModuleName        = types.ModuleName
+	StoreKey          = types.StoreKey
+	QuerierRoute      = types.QuerierRoute
+	RouterKey         = types.RouterKey
+	DefaultParamspace = types.DefaultParamspace
+	QueryParams       = types.QueryParams
+	QueryProphecy     = types.QueryProphecy
+	QueryProphecies   = types.QueryProphecies
+	QueryMisbehaviors = types.QueryMisbehaviors
+	QueryLiveness     = types.QueryLiveness
+	FlagStatus        = types.FlagStatus
+	FlagValidator     = types.FlagValidator
+	FlagNamespace     = types.FlagNamespace
+	PendingStatusText = types.PendingStatusText
+	SuccessStatusText = types.SuccessStatusText
+	FailedStatusText  = types.FailedStatusText
+	ExpiredStatusText = types.ExpiredStatusText
+
+	MaxProphecyIDLength               = types.MaxProphecyIDLength
+	MaxNamespaceLength                = types.MaxNamespaceLength
+	ExactMatchAggregation             = types.ExactMatchAggregation
+	WeightedMedianAggregation         = types.WeightedMedianAggregation
+	DefaultProphecyExpiryBlocks       = types.DefaultProphecyExpiryBlocks
+	DefaultMaxExpiriesPerBlock        = types.DefaultMaxExpiriesPerBlock
+	DefaultMaxRetalliesPerBlock       = types.DefaultMaxRetalliesPerBlock
+	DefaultMaxLivenessUpdatesPerBlock = types.DefaultMaxLivenessUpdatesPerBlock
+	DefaultCommitWindowBlocks         = types.DefaultCommitWindowBlocks
+	CommitmentLength                  = types.CommitmentLength
+
+	MisbehaviorPolicyEvidence      = types.MisbehaviorPolicyEvidence
+	MisbehaviorPolicySlash         = types.MisbehaviorPolicySlash
+	DefaultMisbehaviorPolicy       = types.DefaultMisbehaviorPolicy
+	DefaultMisbehaviorJailDuration = types.DefaultMisbehaviorJailDuration
+	DefaultClaimWindow             = types.DefaultClaimWindow
+	DefaultLivenessJailDuration    = types.DefaultLivenessJailDuration
+	DefaultReopenCooldownBlocks    = types.DefaultReopenCooldownBlocks
+
+	EventTypeProphecyExpired   = types.EventTypeProphecyExpired
+	EventTypeProphecyRetallied = types.EventTypeProphecyRetallied
+	EventTypeMisbehavior       = types.EventTypeMisbehavior
+	EventTypeLiveness          = types.EventTypeLiveness
+	EventTypeLivenessJail      = types.EventTypeLivenessJail
+	EventTypeProphecyReopened  = types.EventTypeProphecyReopened
+	EventTypeProphecyResolved  = types.EventTypeProphecyResolved
+	AttributeKeyNamespace      = types.AttributeKeyNamespace
+	AttributeKeyProphecyID     = types.AttributeKeyProphecyID
+	AttributeKeyCreationHeight = types.AttributeKeyCreationHeight
+	AttributeKeyValidator      = types.AttributeKeyValidator
+	AttributeKeyPolicy         = types.AttributeKeyPolicy
+	AttributeKeyMissedClaims   = types.AttributeKeyMissedClaims
+	AttributeKeyRound          = types.AttributeKeyRound
+	AttributeKeyStatus         = types.AttributeKeyStatus
+	AttributeValueCategory     = types.AttributeValueCategory
 )
 
 var (
 	// functions aliases
 
-	NewKeeper         = keeper.NewKeeper
-	CreateTestAddrs   = keeper.CreateTestAddrs
-	CreateTestPubKeys = keeper.CreateTestPubKeys
-	CreateTestKeepers = keeper.CreateTestKeepers
+	NewKeeper            = keeper.NewKeeper
+	NewQuerier           = keeper.NewQuerier
+	RegisterInvariants   = keeper.RegisterInvariants
+	FinalClaimsInvariant = keeper.FinalClaimsInvariant
+	CreateTestAddrs      = keeper.CreateTestAddrs
+	CreateTestPubKeys    = keeper.CreateTestPubKeys
+	CreateTestKeepers    = keeper.CreateTestKeepers
+	CreateTestInput      = keeper.CreateTestInput
 
 	NewClaim                         = types.NewClaim
 	ErrProphecyNotFound              = types.ErrProphecyNotFound
@@ -40,20 +89,96 @@ var (
 	ErrInvalidClaim                  = types.ErrInvalidClaim
 	ErrInvalidValidator              = types.ErrInvalidValidator
 	ErrInternalDB                    = types.ErrInternalDB
+	ErrInvalidNamespace              = types.ErrInvalidNamespace
+	ErrUnknownNamespace              = types.ErrUnknownNamespace
+	ErrCommitRequired                = types.ErrCommitRequired
+	ErrCommitWindowClosed            = types.ErrCommitWindowClosed
+	ErrRevealTooEarly                = types.ErrRevealTooEarly
+	ErrInvalidReveal                 = types.ErrInvalidReveal
+	ErrInvalidResolution             = types.ErrInvalidResolution
+	NewClaimCommit                   = types.NewClaimCommit
+	NewValidatorCommit               = types.NewValidatorCommit
+	ClaimCommitment                  = types.ClaimCommitment
 	NewProphecy                      = types.NewProphecy
+	NewProphecyRound                 = types.NewProphecyRound
+	CanReopen                        = types.CanReopen
+	NewValidatorPower                = types.NewValidatorPower
 	NewStatus                        = types.NewStatus
+	NewParams                        = types.NewParams
+	DefaultParams                    = types.DefaultParams
+	ParamKeyTable                    = types.ParamKeyTable
+	NewGenesisState                  = types.NewGenesisState
+	DefaultGenesisState              = types.DefaultGenesisState
+	ValidateGenesis                  = types.ValidateGenesis
+	RegisterCodec                    = types.RegisterCodec
+	ValidateProphecyID               = types.ValidateProphecyID
+	NewQueryProphecyParams           = types.NewQueryProphecyParams
+	NewQueryPropheciesParams         = types.NewQueryPropheciesParams
+	NewQueryMisbehaviorsParams       = types.NewQueryMisbehaviorsParams
+	NewMisbehavior                   = types.NewMisbehavior
+	NewQueryLivenessParams           = types.NewQueryLivenessParams
+	NewValidatorLiveness             = types.NewValidatorLiveness
+	NewMissedClaim                   = types.NewMissedClaim
+	NewMultiOracleHooks              = types.NewMultiOracleHooks
+	ValidateNamespace                = types.ValidateNamespace
+	ScopedProphecyID                 = types.ScopedProphecyID
+	SplitScopedProphecyID            = types.SplitScopedProphecyID
 
 	// variable aliases
 
-	StatusTextToString = types.StatusTextToString
-	StringToStatusText = types.StringToStatusText
+	DefaultConsensusNeeded          = types.DefaultConsensusNeeded
+	DefaultMisbehaviorSlashFraction = types.DefaultMisbehaviorSlashFraction
+	DefaultMinClaimedPerWindow      = types.DefaultMinClaimedPerWindow
+	DefaultRejectionThreshold       = types.DefaultRejectionThreshold
+	KeyConsensusNeeded              = types.KeyConsensusNeeded
+	KeyProphecyExpiryBlocks         = types.KeyProphecyExpiryBlocks
+	KeyMaxExpiriesPerBlock          = types.KeyMaxExpiriesPerBlock
+	KeyMaxRetalliesPerBlock         = types.KeyMaxRetalliesPerBlock
+	KeyMaxLivenessUpdatesPerBlock   = types.KeyMaxLivenessUpdatesPerBlock
+	KeyMisbehaviorPolicy            = types.KeyMisbehaviorPolicy
+	KeyMisbehaviorSlashFraction     = types.KeyMisbehaviorSlashFraction
+	KeyMisbehaviorJailDuration      = types.KeyMisbehaviorJailDuration
+	KeyClaimWindow                  = types.KeyClaimWindow
+	KeyMinClaimedPerWindow          = types.KeyMinClaimedPerWindow
+	KeyLivenessJailDuration         = types.KeyLivenessJailDuration
+	KeyCommitWindowBlocks           = types.KeyCommitWindowBlocks
+	KeyRejectionThreshold           = types.KeyRejectionThreshold
+	KeyReopenCooldownBlocks         = types.KeyReopenCooldownBlocks
+	ModuleCdc                       = types.ModuleCdc
+	StatusTextToString              = types.StatusTextToString
+	StringToStatusText              = types.StringToStatusText
 )
 
 type (
-	Keeper     = keeper.Keeper
-	Claim      = types.Claim
-	Prophecy   = types.Prophecy
-	DBProphecy = types.DBProphecy
-	Status     = types.Status
-	StatusText = types.StatusText
+	Keeper                  = keeper.Keeper
+	Hooks                   = keeper.Hooks
+	TestInput               = keeper.TestInput
+	Claim                   = types.Claim
+	Prophecy                = types.Prophecy
+	DBProphecy              = types.DBProphecy
+	ProphecyRound           = types.ProphecyRound
+	ValidatorPower          = types.ValidatorPower
+	ValidatorClaim          = types.ValidatorClaim
+	ClaimCommit             = types.ClaimCommit
+	ValidatorCommit         = types.ValidatorCommit
+	LegacyDBProphecy        = types.LegacyDBProphecy
+	Misbehavior             = types.Misbehavior
+	ValidatorLiveness       = types.ValidatorLiveness
+	MissedClaim             = types.MissedClaim
+	OracleHooks             = types.OracleHooks
+	MultiOracleHooks        = types.MultiOracleHooks
+	ClaimContentType        = types.ClaimContentType
+	IndexedClaimContentType = types.IndexedClaimContentType
+	StringContentType       = types.StringContentType
+	DecimalContentType      = types.DecimalContentType
+	AggregationMode         = types.AggregationMode
+	Status                  = types.Status
+	StatusText              = types.StatusText
+	Params                  = types.Params
+	GenesisState            = types.GenesisState
+
+	QueryProphecyParams     = types.QueryProphecyParams
+	QueryPropheciesParams   = types.QueryPropheciesParams
+	QueryMisbehaviorsParams = types.QueryMisbehaviorsParams
+	QueryLivenessParams     = types.QueryLivenessParams
 )
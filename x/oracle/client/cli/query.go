@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// GetCmdQueryParams queries the current oracle parameters
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current oracle parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParams)
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryProphecy queries a prophecy by its namespace and id
+func GetCmdQueryProphecy(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prophecy [namespace] [id]",
+		Short: "Query a prophecy by its namespace and id",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryProphecyParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryProphecy)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.Prophecy
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryProphecies queries a page of prophecies, optionally filtered by status and namespace
+func GetCmdQueryProphecies(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "prophecies --status [pending|success|failed|expired] --namespace [namespace] " +
+			"--page [page] --limit [limit]",
+		Short: "Query prophecies, optionally filtered by status and namespace",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			status := viper.GetString(types.FlagStatus)
+			if _, ok := types.StringToStatusText[status]; status != "" && !ok {
+				return fmt.Errorf("invalid prophecy status %s", status)
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit), status, viper.GetString(types.FlagNamespace)))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryProphecies)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.Prophecy
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(types.FlagStatus, "", "Filter prophecies by status: pending, success, failed or expired")
+	cmd.Flags().String(types.FlagNamespace, "", "Filter prophecies by namespace")
+	cmd.Flags().Int(flags.FlagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, 100, "Query number of prophecies per page")
+
+	return cmd
+}
+
+// GetCmdQueryMisbehaviors queries a page of misbehavior records, optionally filtered by validator
+func GetCmdQueryMisbehaviors(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "misbehaviors --validator [validator] --page [page] --limit [limit]",
+		Short: "Query validators' claims that contradicted the final claim of a prophecy, optionally by validator",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var validator sdk.ValAddress
+			if validatorBech32 := viper.GetString(types.FlagValidator); validatorBech32 != "" {
+				var err error
+				if validator, err = sdk.ValAddressFromBech32(validatorBech32); err != nil {
+					return err
+				}
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryMisbehaviorsParams(
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit), validator))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryMisbehaviors)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.Misbehavior
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(types.FlagValidator, "", "Filter misbehaviors by validator operator address")
+	cmd.Flags().Int(flags.FlagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, 100, "Query number of misbehaviors per page")
+
+	return cmd
+}
+
+// GetCmdQueryLiveness queries a page of validator liveness records, optionally for a single validator
+func GetCmdQueryLiveness(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "liveness --validator [validator] --page [page] --limit [limit]",
+		Short: "Query the claims validators missed on recently finalized prophecies, optionally by validator",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var validator sdk.ValAddress
+			if validatorBech32 := viper.GetString(types.FlagValidator); validatorBech32 != "" {
+				var err error
+				if validator, err = sdk.ValAddressFromBech32(validatorBech32); err != nil {
+					return err
+				}
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryLivenessParams(
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit), validator))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryLiveness)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.ValidatorLiveness
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(types.FlagValidator, "", "Query the liveness record of a validator operator address")
+	cmd.Flags().Int(flags.FlagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, 100, "Query number of liveness records per page")
+
+	return cmd
+}
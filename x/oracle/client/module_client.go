@@ -0,0 +1,36 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/gorilla/mux"
+	"github.com/sifchain/peggy/x/oracle/client/cli"
+	"github.com/sifchain/peggy/x/oracle/client/rest"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	// Group oracle queries under a subcommand
+	oracleQueryCmd := &cobra.Command{
+		Use:   "oracle",
+		Short: "Querying commands for the oracle module",
+	}
+
+	oracleQueryCmd.AddCommand(flags.GetCommands(
+		cli.GetCmdQueryParams(queryRoute, cdc),
+		cli.GetCmdQueryProphecy(queryRoute, cdc),
+		cli.GetCmdQueryProphecies(queryRoute, cdc),
+		cli.GetCmdQueryMisbehaviors(queryRoute, cdc),
+		cli.GetCmdQueryLiveness(queryRoute, cdc),
+	)...)
+
+	return oracleQueryCmd
+}
+
+// RegisterRESTRoutes - Central function to define routes that get registered by the main application
+func RegisterRESTRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
+	rest.RegisterRESTRoutes(cliCtx, r, queryRoute)
+}
@@ -0,0 +1,32 @@
+package oracle
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis initializes the oracle module's state from a given genesis state
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	for _, prophecy := range data.Prophecies {
+		keeper.SetProphecy(ctx, prophecy)
+	}
+	for _, misbehavior := range data.Misbehaviors {
+		keeper.SetMisbehavior(ctx, misbehavior)
+	}
+	for _, liveness := range data.ValidatorLiveness {
+		keeper.SetValidatorLiveness(ctx, liveness)
+	}
+	for _, missedClaim := range data.MissedClaims {
+		keeper.SetMissedClaim(ctx, missedClaim.Validator, missedClaim.Index, true)
+	}
+}
+
+// ExportGenesis returns the oracle module's genesis state for the current context. The liveness queue is not
+// exported, the prophecies left in it are counted in the claim windows of their validators first.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	keeper.ProcessLivenessQueue(ctx, math.MaxUint64)
+	return NewGenesisState(keeper.GetParams(ctx), keeper.GetProphecies(ctx), keeper.GetMisbehaviors(ctx),
+		keeper.GetAllValidatorLiveness(ctx), keeper.GetMissedClaims(ctx))
+}
@@ -0,0 +1,385 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/keeper"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+const (
+	pendingID  = "pendingID"
+	successID  = "successID"
+	failedID   = "failedID"
+	disputedID = "disputedID"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	ctx, oracleKeeper, _, _, _, validatorAddresses := keeper.CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow3 := validatorAddresses[1]
+	validator3Pow4 := validatorAddresses[2]
+
+	processClaims(t, ctx, oracleKeeper,
+		types.NewClaim(keeper.TestNamespace, pendingID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, successID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, successID, validator2Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, failedID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, failedID, validator2Pow3, keeper.AlternateTestString),
+		types.NewClaim(keeper.TestNamespace, failedID, validator3Pow4, keeper.AnotherAlternateTestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator2Pow3, keeper.AlternateTestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator3Pow4, keeper.TestString),
+	)
+
+	genesis := ExportGenesis(ctx, oracleKeeper)
+	require.NoError(t, ValidateGenesis(genesis))
+	require.Len(t, genesis.Prophecies, 4)
+	require.Len(t, genesis.ValidatorLiveness, 3)
+	require.NotEmpty(t, genesis.MissedClaims)
+	require.Equal(t, []Misbehavior{
+		types.NewMisbehavior(keeper.TestNamespace, disputedID, validator2Pow3, keeper.AlternateTestString,
+			keeper.TestString, ctx.BlockHeight()),
+	}, genesis.Misbehaviors)
+
+	// Round trip the exported state through JSON into a fresh chain
+	bz := ModuleCdc.MustMarshalJSON(genesis)
+	var importedGenesis GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &importedGenesis)
+	require.NoError(t, ValidateGenesis(importedGenesis))
+
+	newCtx, newKeeper, _, _, _, _ := keeper.CreateTestKeepers(t, 0.7, []int64{3, 3, 4}, "")
+	InitGenesis(newCtx, newKeeper, importedGenesis)
+	require.Equal(t, genesis, ExportGenesis(newCtx, newKeeper))
+
+	// Finalized prophecies cannot be replayed after the import
+	for _, id := range []string{successID, failedID, disputedID} {
+		_, err := newKeeper.ProcessClaim(newCtx, types.NewClaim(keeper.TestNamespace, id, validator3Pow4, keeper.TestString))
+		require.Error(t, err)
+		require.True(t, types.ErrProphecyFinalized.Is(err))
+	}
+
+	// Pending prophecies keep collecting claims where they left off
+	status, err := newKeeper.ProcessClaim(newCtx,
+		types.NewClaim(keeper.TestNamespace, pendingID, validator3Pow4, keeper.TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.Equal(t, keeper.TestString, status.FinalClaim)
+}
+
+func TestExportImportResolvedProphecies(t *testing.T) {
+	ctx, oracleKeeper, _, _, _, validatorAddresses := keeper.CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+
+	processClaims(t, ctx, oracleKeeper,
+		types.NewClaim(keeper.TestNamespace, successID, validatorAddresses[0], keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, failedID, validatorAddresses[0], keeper.TestString),
+	)
+
+	// governance can resolve a prophecy with a final claim no validator made
+	require.NoError(t, oracleKeeper.ResolveProphecy(ctx, keeper.TestNamespace, successID,
+		types.NewStatus(types.SuccessStatusText, keeper.AlternateTestString)))
+	require.NoError(t, oracleKeeper.ResolveProphecy(ctx, keeper.TestNamespace, failedID,
+		types.NewStatus(types.FailedStatusText, "")))
+
+	genesis := ExportGenesis(ctx, oracleKeeper)
+	require.NoError(t, ValidateGenesis(genesis))
+	require.Len(t, genesis.Prophecies, 2)
+	for _, prophecy := range genesis.Prophecies {
+		require.True(t, prophecy.Status.Resolved)
+	}
+
+	bz := ModuleCdc.MustMarshalJSON(genesis)
+	var importedGenesis GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &importedGenesis)
+	require.NoError(t, ValidateGenesis(importedGenesis))
+
+	newCtx, newKeeper, _, _, _, _ := keeper.CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	InitGenesis(newCtx, newKeeper, importedGenesis)
+	require.Equal(t, genesis, ExportGenesis(newCtx, newKeeper))
+
+	prophecy, found := newKeeper.GetProphecy(newCtx, keeper.TestNamespace, successID)
+	require.True(t, found)
+	require.Equal(t, keeper.AlternateTestString, prophecy.Status.FinalClaim)
+}
+
+func TestExportImportGenesisForZeroHeight(t *testing.T) {
+	ctx, oracleKeeper, _, _, _, validatorAddresses := keeper.CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	params := oracleKeeper.GetParams(ctx)
+	params.ProphecyExpiryBlocks = 20
+	oracleKeeper.SetParams(ctx, params)
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow3 := validatorAddresses[1]
+	validator3Pow4 := validatorAddresses[2]
+
+	processClaims(t, ctx.WithBlockHeight(100), oracleKeeper,
+		types.NewClaim(keeper.TestNamespace, pendingID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator1Pow3, keeper.TestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator2Pow3, keeper.AlternateTestString),
+		types.NewClaim(keeper.TestNamespace, disputedID, validator3Pow4, keeper.TestString),
+	)
+
+	// one commit window closed before the export, the other is still open
+	params.CommitWindowBlocks = 5
+	oracleKeeper.SetParams(ctx, params)
+	salt := "salt"
+	for _, commit := range []struct {
+		height int64
+		id     string
+	}{{100, "closedCommitID"}, {103, "openCommitID"}} {
+		_, err := oracleKeeper.ProcessCommit(ctx.WithBlockHeight(commit.height), types.NewClaimCommit(
+			keeper.TestNamespace, commit.id, validator1Pow3, types.ClaimCommitment(salt, validator1Pow3, keeper.TestString)))
+		require.NoError(t, err)
+	}
+
+	genesis := ExportGenesis(ctx.WithBlockHeight(106), oracleKeeper).RebaseHeights(106)
+	require.NoError(t, ValidateGenesis(genesis))
+
+	heights := make(map[string][3]int64)
+	for _, prophecy := range genesis.Prophecies {
+		heights[prophecy.ID] = [3]int64{prophecy.CreationHeight, prophecy.CommitEndHeight, prophecy.FinalizedHeight}
+		for _, claim := range prophecy.Claims {
+			require.Equal(t, int64(0), claim.Height)
+		}
+	}
+	require.Equal(t, map[string][3]int64{
+		pendingID:        {0, 0, 0},
+		disputedID:       {0, 0, 0},
+		"closedCommitID": {0, 1, 0},
+		"openCommitID":   {0, 2, 0},
+	}, heights)
+	require.Len(t, genesis.Misbehaviors, 1)
+	require.Equal(t, int64(0), genesis.Misbehaviors[0].Height)
+
+	bz := ModuleCdc.MustMarshalJSON(genesis)
+	var importedGenesis GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &importedGenesis)
+	newCtx, newKeeper, _, _, _, _ := keeper.CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	InitGenesis(newCtx, newKeeper, importedGenesis)
+	newCtx = newCtx.WithBlockHeight(1)
+
+	// commit windows keep the number of blocks they had left
+	_, err := newKeeper.RevealClaim(newCtx,
+		types.NewClaim(keeper.TestNamespace, "closedCommitID", validator1Pow3, keeper.TestString), salt)
+	require.NoError(t, err)
+	_, err = newKeeper.RevealClaim(newCtx,
+		types.NewClaim(keeper.TestNamespace, "openCommitID", validator1Pow3, keeper.TestString), salt)
+	require.True(t, types.ErrRevealTooEarly.Is(err))
+	_, err = newKeeper.ProcessCommit(newCtx, types.NewClaimCommit(keeper.TestNamespace, "openCommitID",
+		validator2Pow3, types.ClaimCommitment(salt, validator2Pow3, keeper.TestString)))
+	require.NoError(t, err)
+
+	// pending prophecies expire after the expiry blocks of the restarted chain
+	EndBlocker(newCtx.WithBlockHeight(19), newKeeper)
+	prophecy, found := newKeeper.GetProphecy(newCtx, keeper.TestNamespace, pendingID)
+	require.True(t, found)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	EndBlocker(newCtx.WithBlockHeight(20), newKeeper)
+	prophecy, found = newKeeper.GetProphecy(newCtx, keeper.TestNamespace, pendingID)
+	require.True(t, found)
+	require.Equal(t, types.ExpiredStatusText, prophecy.Status.Text)
+}
+
+func TestValidateGenesis(t *testing.T) {
+	_, validatorAddresses := keeper.CreateTestAddrs(2)
+	validator1 := validatorAddresses[0]
+	validator2 := validatorAddresses[1]
+
+	newProphecy := func(id string, status types.Status, claims map[string]sdk.ValAddress) types.Prophecy {
+		prophecy := types.NewProphecy(keeper.TestNamespace, id)
+		prophecy.Status = status
+		for claim, validator := range claims {
+			prophecy.AddClaim(validator, claim, 1)
+		}
+		return prophecy
+	}
+
+	inNamespace := func(namespace string, prophecy types.Prophecy) types.Prophecy {
+		prophecy.Namespace = namespace
+		return prophecy
+	}
+
+	withCommits := func(commitEndHeight int64, prophecy types.Prophecy, validators ...sdk.ValAddress) types.Prophecy {
+		prophecy.CommitEndHeight = commitEndHeight
+		for _, validator := range validators {
+			prophecy.AddCommit(validator, types.ClaimCommitment("salt", validator, keeper.TestString))
+		}
+		return prophecy
+	}
+
+	withRejections := func(prophecy types.Prophecy, validators ...sdk.ValAddress) types.Prophecy {
+		prophecy.ValidatorPowers = []types.ValidatorPower{
+			types.NewValidatorPower(validator1, 1), types.NewValidatorPower(validator2, 1),
+		}
+		for _, validator := range validators {
+			prophecy.AddRejection(validator)
+		}
+		return prophecy
+	}
+
+	withPastRounds := func(prophecy types.Prophecy, statuses ...types.StatusText) types.Prophecy {
+		for round, status := range statuses {
+			pastRound := types.NewProphecy(prophecy.Namespace, prophecy.ID)
+			pastRound.Round = uint64(round)
+			pastRound.Status = types.NewStatus(status, "")
+			prophecy.PastRounds = append(prophecy.PastRounds, types.NewProphecyRound(pastRound))
+		}
+		prophecy.Round = uint64(len(statuses))
+		return prophecy
+	}
+
+	pending := types.NewStatus(types.PendingStatusText, "")
+	success := types.NewStatus(types.SuccessStatusText, keeper.TestString)
+	resolved := func(status types.Status) types.Status {
+		status.Resolved = true
+		return status
+	}
+
+	invalidParams := DefaultParams()
+	invalidParams.ConsensusNeeded = sdk.ZeroDec()
+	invalidPolicyParams := DefaultParams()
+	invalidPolicyParams.MisbehaviorPolicy = "ignore"
+
+	testCases := []struct {
+		name     string
+		genesis  GenesisState
+		expError bool
+	}{
+		{"default genesis", DefaultGenesisState(), false},
+		{"valid prophecies", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+			newProphecy(successID, success, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+		}, nil, nil, nil), false},
+		{"invalid params", NewGenesisState(invalidParams, []Prophecy{}, nil, nil, nil), true},
+		{"invalid misbehavior policy", NewGenesisState(invalidPolicyParams, []Prophecy{}, nil, nil, nil), true},
+		{"duplicate ids", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+			newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator2}),
+		}, nil, nil, nil), true},
+		{"same id in distinct namespaces", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+			inNamespace("other", newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1})),
+		}, nil, nil, nil), false},
+		{"invalid namespace", NewGenesisState(DefaultParams(), []Prophecy{
+			inNamespace("Other/", newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1})),
+		}, nil, nil, nil), true},
+		{"valid commits", NewGenesisState(DefaultParams(), []Prophecy{
+			withCommits(5, newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				validator1, validator2),
+		}, nil, nil, nil), false},
+		{"commits without commit window", NewGenesisState(DefaultParams(), []Prophecy{
+			withCommits(0, newProphecy(pendingID, pending, nil), validator1),
+		}, nil, nil, nil), true},
+		{"claim without commit", NewGenesisState(DefaultParams(), []Prophecy{
+			withCommits(5, newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				validator2),
+		}, nil, nil, nil), true},
+		{"valid rejections", NewGenesisState(DefaultParams(), []Prophecy{
+			withRejections(newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				validator2),
+		}, nil, nil, nil), false},
+		{"claim and rejection of a validator", NewGenesisState(DefaultParams(), []Prophecy{
+			withRejections(newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				validator1),
+		}, nil, nil, nil), true},
+		{"valid past rounds", NewGenesisState(DefaultParams(), []Prophecy{
+			withPastRounds(newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				types.FailedStatusText, types.ExpiredStatusText),
+		}, nil, nil, nil), false},
+		{"successful past round", NewGenesisState(DefaultParams(), []Prophecy{
+			withPastRounds(newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+				types.SuccessStatusText),
+		}, nil, nil, nil), true},
+		{"empty id", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy("", pending, map[string]sdk.ValAddress{keeper.TestString: validator1}),
+		}, nil, nil, nil), true},
+		{"success without matching claim", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(successID, success, map[string]sdk.ValAddress{keeper.AlternateTestString: validator1}),
+		}, nil, nil, nil), true},
+		{"resolved success without matching claim", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(successID, resolved(success), map[string]sdk.ValAddress{keeper.AlternateTestString: validator1}),
+		}, nil, nil, nil), false},
+		{"resolved pending", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(pendingID, resolved(pending), map[string]sdk.ValAddress{keeper.TestString: validator1}),
+		}, nil, nil, nil), true},
+		{"pending with final claim", NewGenesisState(DefaultParams(), []Prophecy{
+			newProphecy(pendingID, types.NewStatus(types.PendingStatusText, keeper.TestString),
+				map[string]sdk.ValAddress{keeper.TestString: validator1}),
+		}, nil, nil, nil), true},
+		{"valid misbehaviors", NewGenesisState(DefaultParams(), []Prophecy{}, []Misbehavior{
+			types.NewMisbehavior(keeper.TestNamespace, successID,
+				validator1, keeper.AlternateTestString, keeper.TestString, 1),
+			types.NewMisbehavior(keeper.TestNamespace, successID,
+				validator2, keeper.AlternateTestString, keeper.TestString, 1),
+		}, nil, nil), false},
+		{"duplicate misbehaviors", NewGenesisState(DefaultParams(), []Prophecy{}, []Misbehavior{
+			types.NewMisbehavior(keeper.TestNamespace, successID,
+				validator1, keeper.AlternateTestString, keeper.TestString, 1),
+			types.NewMisbehavior(keeper.TestNamespace, successID,
+				validator1, keeper.AnotherAlternateTestString, keeper.TestString, 1),
+		}, nil, nil), true},
+		{"misbehavior agreeing with the final claim", NewGenesisState(DefaultParams(), []Prophecy{}, []Misbehavior{
+			types.NewMisbehavior(keeper.TestNamespace, successID,
+				validator1, keeper.TestString, keeper.TestString, 1),
+		}, nil, nil), true},
+		{"valid claim windows", NewGenesisState(DefaultParams(), []Prophecy{}, nil, []ValidatorLiveness{
+			types.NewValidatorLiveness(validator1, 3, 2), types.NewValidatorLiveness(validator2, 3, 0),
+		}, []MissedClaim{types.NewMissedClaim(validator1, 0), types.NewMissedClaim(validator1, 2)}), false},
+		{"missed claims counter mismatch", NewGenesisState(DefaultParams(), []Prophecy{}, nil, []ValidatorLiveness{
+			types.NewValidatorLiveness(validator1, 3, 1),
+		}, []MissedClaim{types.NewMissedClaim(validator1, 0), types.NewMissedClaim(validator1, 2)}), true},
+		{"missed claim without liveness record", NewGenesisState(DefaultParams(), []Prophecy{}, nil, nil,
+			[]MissedClaim{types.NewMissedClaim(validator1, 0)}), true},
+		{"missed claim outside the window", NewGenesisState(DefaultParams(), []Prophecy{}, nil, []ValidatorLiveness{
+			types.NewValidatorLiveness(validator1, 3, 1),
+		}, []MissedClaim{types.NewMissedClaim(validator1, types.DefaultClaimWindow)}), true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGenesis(tc.genesis)
+			if tc.expError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// Claims must be sorted by unique validator address
+	prophecy := newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1})
+	prophecy.Claims = append(prophecy.Claims, types.NewValidatorClaim(validator1, keeper.AlternateTestString, 1))
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), []Prophecy{prophecy}, nil, nil, nil)))
+
+	// Validator power snapshots must be sorted, positive and contain every claiming validator
+	prophecy = newProphecy(pendingID, pending, map[string]sdk.ValAddress{keeper.TestString: validator1})
+	prophecy.ValidatorPowers = []types.ValidatorPower{
+		types.NewValidatorPower(validator1, 3), types.NewValidatorPower(validator2, 7),
+	}
+	require.NoError(t, ValidateGenesis(NewGenesisState(DefaultParams(), []Prophecy{prophecy}, nil, nil, nil)))
+
+	prophecy.ValidatorPowers = []types.ValidatorPower{
+		types.NewValidatorPower(validator2, 7), types.NewValidatorPower(validator1, 3),
+	}
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), []Prophecy{prophecy}, nil, nil, nil)))
+
+	prophecy.ValidatorPowers = []types.ValidatorPower{
+		types.NewValidatorPower(validator1, 0), types.NewValidatorPower(validator2, 7),
+	}
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), []Prophecy{prophecy}, nil, nil, nil)))
+
+	prophecy.ValidatorPowers = []types.ValidatorPower{types.NewValidatorPower(validator2, 7)}
+	require.Error(t, ValidateGenesis(NewGenesisState(DefaultParams(), []Prophecy{prophecy}, nil, nil, nil)))
+}
+
+func processClaims(t *testing.T, ctx sdk.Context, oracleKeeper Keeper, claims ...types.Claim) {
+	for _, claim := range claims {
+		_, err := oracleKeeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestCommitRevealClaims(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 3, 4}, "")
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow3 := validatorAddresses[1]
+	validator3Pow4 := validatorAddresses[2]
+
+	commitment := func(validator []byte, salt string) []byte {
+		return types.ClaimCommitment(salt, validator, TestString)
+	}
+
+	// commitments are rejected while commit-reveal voting is disabled
+	ctx = ctx.WithBlockHeight(1)
+	_, err := keeper.ProcessCommit(ctx,
+		types.NewClaimCommit(TestNamespace, TestID, validator1Pow3, commitment(validator1Pow3, "salt1")))
+	require.True(t, types.ErrCommitWindowClosed.Is(err))
+
+	params := keeper.GetParams(ctx)
+	params.CommitWindowBlocks = 5
+	keeper.SetParams(ctx, params)
+
+	// and claims must then be committed first
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.True(t, types.ErrCommitRequired.Is(err))
+
+	_, err = keeper.ProcessCommit(ctx, types.NewClaimCommit(TestNamespace, TestID, validator1Pow3, []byte("short")))
+	require.Error(t, err)
+
+	for i, validator := range []types.ValidatorCommit{
+		types.NewValidatorCommit(validator1Pow3, commitment(validator1Pow3, "salt1")),
+		types.NewValidatorCommit(validator2Pow3, commitment(validator2Pow3, "salt2")),
+		types.NewValidatorCommit(validator3Pow4, types.ClaimCommitment("salt3", validator3Pow4, "other")),
+	} {
+		status, err := keeper.ProcessCommit(ctx.WithBlockHeight(int64(1+i)),
+			types.NewClaimCommit(TestNamespace, TestID, validator.Validator, validator.Commitment))
+		require.NoError(t, err)
+		require.Equal(t, types.PendingStatusText, status.Text)
+	}
+	_, err = keeper.ProcessCommit(ctx,
+		types.NewClaimCommit(TestNamespace, TestID, validator1Pow3, commitment(validator1Pow3, "salt1")))
+	require.True(t, types.ErrDuplicateMessage.Is(err))
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, int64(6), prophecy.CommitEndHeight)
+	require.Len(t, prophecy.Commits, 3)
+	require.Empty(t, prophecy.Claims)
+
+	// claims are neither committed nor revealed outside of their windows
+	_, err = keeper.RevealClaim(ctx.WithBlockHeight(5),
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString), "salt1")
+	require.True(t, types.ErrRevealTooEarly.Is(err))
+	ctx = ctx.WithBlockHeight(6)
+	_, err = keeper.ProcessCommit(ctx,
+		types.NewClaimCommit(TestNamespace, TestID, validator1Pow3, commitment(validator1Pow3, "salt1")))
+	require.True(t, types.ErrCommitWindowClosed.Is(err))
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.True(t, types.ErrCommitRequired.Is(err))
+
+	// reveals must match the commitment of their validator
+	_, err = keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString), "salt2")
+	require.True(t, types.ErrInvalidReveal.Is(err))
+	_, err = keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString), "salt3")
+	require.True(t, types.ErrInvalidReveal.Is(err))
+
+	status, err := keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString), "salt1")
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	_, err = keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString), "salt1")
+	require.True(t, types.ErrDuplicateMessage.Is(err))
+
+	status, err = keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator3Pow4, "other"), "salt3")
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	status, err = keeper.RevealClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow3, TestString), "salt2")
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, status.Text)
+
+	// commitments are kept with the claims they revealed
+	prophecy, found = keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Len(t, prophecy.Commits, 3)
+	require.Len(t, prophecy.Claims, 3)
+}
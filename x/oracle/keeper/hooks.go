@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// Hooks wrapper struct for the oracle keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ stakingtypes.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the oracle keeper, which record validators leaving the bonded validator set so
+// that pending prophecies are re-tallied without their unclaimed power. Other staking changes do not affect pending
+// prophecies: validators joining the set are not in their snapshots, and delegation changes and slashes do not
+// change snapshotted powers.
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterValidatorBeginUnbonding implements the stakingtypes.StakingHooks interface
+func (h Hooks) AfterValidatorBeginUnbonding(ctx sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress) {
+	h.k.setValidatorSetChanged(ctx)
+}
+
+// nolint - unused hooks
+func (h Hooks) AfterValidatorCreated(_ sdk.Context, _ sdk.ValAddress)                            {}
+func (h Hooks) BeforeValidatorModified(_ sdk.Context, _ sdk.ValAddress)                          {}
+func (h Hooks) AfterValidatorRemoved(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)         {}
+func (h Hooks) AfterValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)          {}
+func (h Hooks) BeforeDelegationCreated(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) BeforeDelegationSharesModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {}
+func (h Hooks) BeforeDelegationRemoved(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) AfterDelegationModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) BeforeValidatorSlashed(_ sdk.Context, _ sdk.ValAddress, _ sdk.Dec)                {}
+
+// setValidatorSetChanged has the pending prophecies re-tallied from the first, as prophecies already re-tallied
+// since the last change may be affected by this one
+func (k Keeper) setValidatorSetChanged(ctx sdk.Context) {
+	ctx.KVStore(k.storeKey).Set(types.ValidatorSetChangedKey, []byte{})
+}
@@ -0,0 +1,216 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// testOracleHooks records the prophecies it is called for, and stores a marker for each of them so that tests can
+// check whether the state changes of failing hooks were kept
+type testOracleHooks struct {
+	storeKey  sdk.StoreKey
+	succeeded map[string]string
+	failed    map[string]types.StatusText
+	err       error
+}
+
+var _ types.OracleHooks = &testOracleHooks{}
+
+func newTestOracleHooks(storeKey sdk.StoreKey) *testOracleHooks {
+	return &testOracleHooks{
+		storeKey:  storeKey,
+		succeeded: make(map[string]string),
+		failed:    make(map[string]types.StatusText),
+	}
+}
+
+func (h *testOracleHooks) AfterProphecySucceeded(ctx sdk.Context, _, id string, finalClaim string) error {
+	h.succeeded[id] = finalClaim
+	ctx.KVStore(h.storeKey).Set([]byte("hook"+id), []byte{})
+	return h.err
+}
+
+func (h *testOracleHooks) AfterProphecyFailed(ctx sdk.Context, _, id string, status types.StatusText) error {
+	h.failed[id] = status
+	ctx.KVStore(h.storeKey).Set([]byte("hook"+id), []byte{})
+	return h.err
+}
+
+func TestOracleHooksOnClaims(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+	hooks := newTestOracleHooks(keeper.storeKey)
+	keeper.SetHooks(hooks)
+	require.Panics(t, func() { keeper.SetHooks(hooks) })
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, TestID, validator2Pow3, TestString),
+		types.NewClaim(TestNamespace, AlternateTestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, AlternateTestID, validator2Pow3, AlternateTestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+	require.Equal(t, map[string]string{TestID: TestString}, hooks.succeeded)
+	require.Empty(t, hooks.failed)
+
+	_, err := keeper.ProcessClaim(ctx,
+		types.NewClaim(TestNamespace, AlternateTestID, validator3Pow4, AnotherAlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, map[string]types.StatusText{AlternateTestID: types.FailedStatusText}, hooks.failed)
+
+	// a failing hook fails the claim that finalized the prophecy
+	hooks.err = errors.New("hook failed")
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, "thirdID", validator3Pow4, TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, "thirdID", validator1Pow3, TestString))
+	require.Equal(t, hooks.err, err)
+}
+
+func TestOracleHooksOnExpiry(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+	hooks := newTestOracleHooks(keeper.storeKey)
+	keeper.SetHooks(hooks)
+
+	params := keeper.GetParams(ctx)
+	params.ProphecyExpiryBlocks = 5
+	keeper.SetParams(ctx, params)
+
+	for _, id := range []string{TestID, AlternateTestID} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, input.ValidatorAddresses[0], TestString))
+		require.NoError(t, err)
+	}
+
+	// prophecies finalized outside of a transaction keep their status when their hooks fail, but the state
+	// changes of the failing hooks are discarded
+	hooks.err = errors.New("hook failed")
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 5)
+	require.Len(t, keeper.ExpireProphecies(ctx), 2)
+	require.Equal(t, map[string]types.StatusText{
+		TestID:          types.ExpiredStatusText,
+		AlternateTestID: types.ExpiredStatusText,
+	}, hooks.failed)
+
+	store := ctx.KVStore(keeper.storeKey)
+	for _, id := range []string{TestID, AlternateTestID} {
+		prophecy, found := keeper.GetProphecy(ctx, TestNamespace, id)
+		require.True(t, found)
+		require.Equal(t, types.ExpiredStatusText, prophecy.Status.Text)
+		require.False(t, store.Has([]byte("hook"+id)))
+	}
+}
+
+func TestOracleHooksOnRetally(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper, stakingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper
+	stakingKeeper.SetHooks(keeper.Hooks())
+	hooks := newTestOracleHooks(keeper.storeKey)
+	keeper.SetHooks(hooks)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, input.ValidatorAddresses[0], TestString))
+	require.NoError(t, err)
+
+	validator2, found := stakingKeeper.GetValidator(ctx, input.ValidatorAddresses[1])
+	require.True(t, found)
+	stakingKeeper.Jail(ctx, validator2.GetConsAddr())
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	require.Len(t, keeper.RetallyPendingProphecies(ctx), 1)
+	require.Equal(t, map[string]types.StatusText{TestID: types.FailedStatusText}, hooks.failed)
+	require.True(t, ctx.KVStore(keeper.storeKey).Has([]byte("hook"+TestID)))
+}
+
+func TestOracleHooksFailingOnSuccess(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+	hooks := newTestOracleHooks(keeper.storeKey)
+	keeper.SetHooks(hooks)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, input.ValidatorAddresses[0], TestString))
+	require.NoError(t, err)
+
+	// a prophecy that succeeds outside of a transaction fails instead when its claim cannot be executed
+	hooks.err = errors.New("hook failed")
+	dbProphecy, found := keeper.getDBProphecy(ctx, types.ScopedProphecyID(TestNamespace, TestID))
+	require.True(t, found)
+	dbProphecy.Status = types.NewStatus(types.SuccessStatusText, TestString)
+	dbProphecy.FinalizedHeight = ctx.BlockHeight()
+	dbProphecy = keeper.finalizeProphecyCached(ctx, dbProphecy)
+	require.Equal(t, types.NewStatus(types.FailedStatusText, ""), dbProphecy.Status)
+	require.Equal(t, map[string]string{TestID: TestString}, hooks.succeeded)
+	require.Equal(t, map[string]types.StatusText{TestID: types.FailedStatusText}, hooks.failed)
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+	require.False(t, ctx.KVStore(keeper.storeKey).Has([]byte("hook"+TestID)))
+	_, broken := FinalClaimsInvariant(keeper)(ctx)
+	require.False(t, broken)
+
+	// governance can execute the claim once the cause is fixed
+	hooks.err = nil
+	require.NoError(t, keeper.ResolveProphecy(ctx, TestNamespace, TestID,
+		types.NewStatus(types.SuccessStatusText, TestString)))
+	require.True(t, ctx.KVStore(keeper.storeKey).Has([]byte("hook"+TestID)))
+}
+
+func TestOracleHooksOnResolution(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+	hooks := newTestOracleHooks(keeper.storeKey)
+	keeper.SetHooks(hooks)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+
+	err := keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.FailedStatusText, ""))
+	require.True(t, types.ErrProphecyNotFound.Is(err))
+
+	for _, id := range []string{TestID, AlternateTestID} {
+		_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, validator1Pow3, TestString))
+		require.NoError(t, err)
+	}
+
+	// pending prophecies can be failed
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.ExpiredStatusText, ""))
+	require.True(t, types.ErrInvalidResolution.Is(err))
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.FailedStatusText, ""))
+	require.NoError(t, err)
+	require.Equal(t, map[string]types.StatusText{TestID: types.FailedStatusText}, hooks.failed)
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.FailedStatusText, ""))
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+
+	// and prophecies that have not succeeded can succeed with a final claim no validator made
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.SuccessStatusText, ""))
+	require.True(t, types.ErrInvalidClaim.Is(err))
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID,
+		types.NewStatus(types.SuccessStatusText, AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{TestID: AlternateTestString}, hooks.succeeded)
+	err = keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.SuccessStatusText, TestString))
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, AlternateTestString, prophecy.Status.FinalClaim)
+	require.True(t, prophecy.Status.Resolved)
+
+	// validators are not held accountable for their votes on resolved prophecies
+	err = keeper.ResolveProphecy(ctx, TestNamespace, AlternateTestID,
+		types.NewStatus(types.SuccessStatusText, AlternateTestString))
+	require.NoError(t, err)
+	require.Empty(t, keeper.GetMisbehaviors(ctx))
+	require.False(t, keeper.GetMissedClaim(ctx, validator2Pow3, 0))
+}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// RegisterInvariants registers the oracle module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "final-claims", FinalClaimsInvariant(k))
+}
+
+// FinalClaimsInvariant checks that every successful prophecy has a final claim in the canonical form of the claims
+// of its namespace, and that the other prophecies and the past rounds of reopened prophecies have none
+func FinalClaimsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		k.IterateProphecies(ctx, func(prophecy types.Prophecy) bool {
+			prophecy.PastRounds = k.getPastRounds(ctx, prophecy.ScopedID())
+			if err := k.validateFinalClaim(prophecy); err != nil {
+				count++
+				msg += fmt.Sprintf("\t%s\n", err)
+			}
+			return false
+		})
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "final-claims", fmt.Sprintf(
+			"%d prophecies with an inconsistent final claim found\n%s", count, msg)), broken
+	}
+}
+
+// validateFinalClaim returns an error if the final claim of the prophecy does not match its status. Final claims
+// of namespaces without a registered content type cannot be normalized, only their presence is checked.
+func (k Keeper) validateFinalClaim(prophecy types.Prophecy) error {
+	for _, pastRound := range prophecy.PastRounds {
+		if pastRound.Status.FinalClaim != "" {
+			return fmt.Errorf("prophecy %s: %s round %d has final claim %s", prophecy.ScopedID(),
+				pastRound.Status.Text, pastRound.Round, pastRound.Status.FinalClaim)
+		}
+	}
+
+	finalClaim := prophecy.Status.FinalClaim
+	if prophecy.Status.Text != types.SuccessStatusText {
+		if finalClaim != "" {
+			return fmt.Errorf("%s prophecy %s has final claim %s", prophecy.Status.Text, prophecy.ScopedID(),
+				finalClaim)
+		}
+		return nil
+	}
+
+	if finalClaim == "" {
+		return fmt.Errorf("successful prophecy %s has no final claim", prophecy.ScopedID())
+	}
+	contentType, found := k.GetNamespaceContentType(prophecy.Namespace)
+	if !found {
+		return nil
+	}
+	normalized, err := contentType.NormalizeContent(finalClaim)
+	if err != nil {
+		return fmt.Errorf("successful prophecy %s has invalid final claim %s: %s", prophecy.ScopedID(), finalClaim,
+			err)
+	}
+	if normalized != finalClaim {
+		return fmt.Errorf("successful prophecy %s has final claim %s instead of %s", prophecy.ScopedID(), finalClaim,
+			normalized)
+	}
+	return nil
+}
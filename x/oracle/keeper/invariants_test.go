@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestFinalClaimsInvariant(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{3, 7}, "")
+	keeper.RegisterNamespace("price", types.DecimalContentType{})
+	invariant := FinalClaimsInvariant(keeper)
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow7 := validatorAddresses[1]
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.NoError(t, err)
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, AlternateTestID, validator2Pow7, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim("price", TestID, validator2Pow7, "10"))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.NoError(t, keeper.ResolveProphecy(ctx, TestNamespace, TestID, types.NewStatus(types.FailedStatusText, "")))
+
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		id        string
+		status    types.Status
+	}{
+		{"failed with a final claim", TestNamespace, TestID, types.NewStatus(types.FailedStatusText, TestString)},
+		{"successful without final claim", TestNamespace, AlternateTestID, types.NewStatus(types.SuccessStatusText, "")},
+		{"final claim not in canonical form", "price", TestID, types.NewStatus(types.SuccessStatusText, "10")},
+		{"invalid final claim", "price", TestID, types.NewStatus(types.SuccessStatusText, "ten")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			dbProphecy, found := keeper.getDBProphecy(cacheCtx, types.ScopedProphecyID(tc.namespace, tc.id))
+			require.True(t, found)
+			dbProphecy.Status = tc.status
+			keeper.setDBProphecy(cacheCtx, dbProphecy)
+
+			msg, broken := invariant(cacheCtx)
+			require.True(t, broken)
+			require.Contains(t, msg, "1 prophecies with an inconsistent final claim found")
+		})
+	}
+
+	// the final claims of past rounds are checked as well
+	cacheCtx, _ := ctx.CacheContext()
+	prophecy, found := keeper.GetProphecy(cacheCtx, TestNamespace, TestID)
+	require.True(t, found)
+	pastRound := types.NewProphecyRound(prophecy)
+	pastRound.Status.FinalClaim = TestString
+	keeper.setPastRound(cacheCtx, prophecy.ScopedID(), pastRound)
+	_, broken = invariant(cacheCtx)
+	require.True(t, broken)
+}
@@ -1,12 +1,16 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/sifchain/peggy/x/oracle/types"
 )
 
@@ -16,98 +20,923 @@ type Keeper struct {
 	cdc      *codec.Codec // The wire codec for binary encoding/decoding.
 	storeKey sdk.StoreKey // Unexposed key to access store from sdk.Context
 
-	stakeKeeper types.StakingKeeper
+	paramSpace params.Subspace // The oracle module parameter subspace
 
-	// TODO: use this as param instead
-	consensusNeeded float64 // The minimum % of stake needed to sign claims in order for consensus to occur
+	stakeKeeper    types.StakingKeeper
+	slashingKeeper types.SlashingKeeper
+
+	hooks types.OracleHooks
+
+	// namespaces holds the claim content types of the registered namespaces. It is shared by the copies of the
+	// keeper so that namespaces registered after the keeper was passed to other modules are seen by them.
+	namespaces map[string]types.ClaimContentType
 }
 
 // NewKeeper creates new instances of the oracle Keeper
 func NewKeeper(
-	cdc *codec.Codec, storeKey sdk.StoreKey, stakeKeeper types.StakingKeeper, consensusNeeded float64,
+	cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, stakeKeeper types.StakingKeeper,
+	slashingKeeper types.SlashingKeeper,
 ) Keeper {
-	if consensusNeeded <= 0 || consensusNeeded > 1 {
-		panic(types.ErrMinimumConsensusNeededInvalid.Error())
-	}
 	return Keeper{
-		cdc:             cdc,
-		storeKey:        storeKey,
-		stakeKeeper:     stakeKeeper,
-		consensusNeeded: consensusNeeded,
+		cdc:            cdc,
+		storeKey:       storeKey,
+		paramSpace:     paramSpace.WithKeyTable(types.ParamKeyTable()),
+		stakeKeeper:    stakeKeeper,
+		slashingKeeper: slashingKeeper,
+		namespaces:     make(map[string]types.ClaimContentType),
 	}
 }
 
+// RegisterNamespace registers a namespace whose claims must be of the given content type. Modules consuming
+// prophecies register their namespace when the app is built, claims of unregistered namespaces are rejected.
+func (k *Keeper) RegisterNamespace(namespace string, contentType types.ClaimContentType) *Keeper {
+	if err := types.ValidateNamespace(namespace); err != nil {
+		panic(fmt.Sprintf("cannot register oracle namespace %q: %s", namespace, err))
+	}
+	if _, found := k.namespaces[namespace]; found {
+		panic(fmt.Sprintf("cannot register oracle namespace %q twice", namespace))
+	}
+	k.namespaces[namespace] = contentType
+	return k
+}
+
+// GetNamespaceContentType returns the claim content type registered for the namespace
+func (k Keeper) GetNamespaceContentType(namespace string) (types.ClaimContentType, bool) {
+	contentType, found := k.namespaces[namespace]
+	return contentType, found
+}
+
+// aggregationMode returns the aggregation mode of the prophecies of the namespace. Prophecies of namespaces that
+// are not registered, such as those stored before namespaces existed, are aggregated by exact match.
+func (k Keeper) aggregationMode(namespace string) types.AggregationMode {
+	if contentType, found := k.namespaces[namespace]; found {
+		return contentType.AggregationMode()
+	}
+	return types.ExactMatchAggregation
+}
+
+// SetHooks sets the hooks called when prophecies are finalized
+func (k *Keeper) SetHooks(oh types.OracleHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set oracle hooks twice")
+	}
+	k.hooks = oh
+	return k
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
 
-// GetProphecy gets the entire prophecy data struct for a given id
-func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
+// GetProphecy gets the entire prophecy data struct for a given namespace and id, with its past rounds
+func (k Keeper) GetProphecy(ctx sdk.Context, namespace, id string) (types.Prophecy, bool) {
+	if types.ValidateProphecyID(namespace, id) != nil {
+		return types.Prophecy{}, false
+	}
+
+	scopedID := types.ScopedProphecyID(namespace, id)
+	prophecy, found := k.getProphecy(ctx, scopedID)
+	if !found {
+		return types.Prophecy{}, false
+	}
+	prophecy.PastRounds = k.getPastRounds(ctx, scopedID)
+	return prophecy, true
+}
+
+// HasProphecy returns whether a prophecy is stored under the given namespace and id
+func (k Keeper) HasProphecy(ctx sdk.Context, namespace, id string) bool {
+	if types.ValidateProphecyID(namespace, id) != nil {
+		return false
+	}
+	return ctx.KVStore(k.storeKey).Has(types.ProphecyKey(types.ScopedProphecyID(namespace, id)))
+}
+
+// getProphecy gets the entire prophecy data struct for a given scoped id. The functions below all take the scoped
+// id of the prophecy, as its data is stored under it.
+func (k Keeper) getProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
+	dbProphecy, found := k.getDBProphecy(ctx, id)
+	if !found {
+		return types.Prophecy{}, false
+	}
+
+	return dbProphecy.ToProphecy(k.getValidatorPowers(ctx, id), k.getClaims(ctx, id), k.getCommits(ctx, id),
+		k.getRejections(ctx, id)), true
+}
+
+func (k Keeper) getDBProphecy(ctx sdk.Context, id string) (types.DBProphecy, bool) {
 	store := ctx.KVStore(k.storeKey)
-	bz := store.Get([]byte(id))
+	bz := store.Get(types.ProphecyKey(id))
 	if bz == nil {
-		return types.Prophecy{}, false
+		return types.DBProphecy{}, false
 	}
 
 	var dbProphecy types.DBProphecy
 	k.cdc.MustUnmarshalBinaryBare(bz, &dbProphecy)
+	return dbProphecy, true
+}
 
-	deSerializedProphecy, err := dbProphecy.DeserializeFromDB()
-	if err != nil {
-		return types.Prophecy{}, false
+// getClaims returns the claims made on the prophecy with the given id in validator address order
+func (k Keeper) getClaims(ctx sdk.Context, id string) []types.ValidatorClaim {
+	var claims []types.ValidatorClaim
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ClaimsKey(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var claim types.ValidatorClaim
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &claim)
+		claims = append(claims, claim)
 	}
+	return claims
+}
+
+// getValidatorPowers returns the validator set snapshot of the prophecy with the given id in validator address order
+func (k Keeper) getValidatorPowers(ctx sdk.Context, id string) []types.ValidatorPower {
+	var validatorPowers []types.ValidatorPower
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ValidatorPowersKey(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var validatorPower types.ValidatorPower
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &validatorPower)
+		validatorPowers = append(validatorPowers, validatorPower)
+	}
+	return validatorPowers
+}
+
+func (k Keeper) getValidatorPower(ctx sdk.Context, id string, validator sdk.ValAddress) (types.ValidatorPower, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.ValidatorPowerKey(id, validator))
+	if bz == nil {
+		return types.ValidatorPower{}, false
+	}
+
+	var validatorPower types.ValidatorPower
+	k.cdc.MustUnmarshalBinaryBare(bz, &validatorPower)
+	return validatorPower, true
+}
+
+func (k Keeper) setValidatorPower(ctx sdk.Context, id string, validatorPower types.ValidatorPower) {
+	ctx.KVStore(k.storeKey).Set(
+		types.ValidatorPowerKey(id, validatorPower.Validator), k.cdc.MustMarshalBinaryBare(validatorPower))
+}
+
+// GetProphecies returns all prophecies in the store with their past rounds
+func (k Keeper) GetProphecies(ctx sdk.Context) []types.Prophecy {
+	var prophecies []types.Prophecy
+	k.IterateProphecies(ctx, func(prophecy types.Prophecy) bool {
+		prophecy.PastRounds = k.getPastRounds(ctx, prophecy.ScopedID())
+		prophecies = append(prophecies, prophecy)
+		return false
+	})
+	return prophecies
+}
 
-	return deSerializedProphecy, true
+// IterateProphecies iterates over all stored prophecies in scoped id order and calls the callback on each of them,
+// stopping when the callback returns true
+func (k Keeper) IterateProphecies(ctx sdk.Context, cb func(prophecy types.Prophecy) (stop bool)) {
+	k.iterateProphecies(ctx, types.ProphecyKeyPrefix, cb)
 }
 
-// setProphecy saves a prophecy with an initial claim
-func (k Keeper) setProphecy(ctx sdk.Context, prophecy types.Prophecy) {
+// IteratePropheciesByNamespace iterates over the prophecies of the namespace in id order and calls the callback
+// on each of them, stopping when the callback returns true
+func (k Keeper) IteratePropheciesByNamespace(
+	ctx sdk.Context, namespace string, cb func(prophecy types.Prophecy) (stop bool),
+) {
+	k.iterateProphecies(ctx, types.ProphecyKey(types.ScopedProphecyID(namespace, "")), cb)
+}
+
+func (k Keeper) iterateProphecies(ctx sdk.Context, prefix []byte, cb func(prophecy types.Prophecy) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var dbProphecy types.DBProphecy
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &dbProphecy)
+
+		id := dbProphecy.ScopedID()
+		prophecy := dbProphecy.ToProphecy(k.getValidatorPowers(ctx, id), k.getClaims(ctx, id), k.getCommits(ctx, id),
+			k.getRejections(ctx, id))
+		if cb(prophecy) {
+			break
+		}
+	}
+}
+
+// IteratePropheciesByStatus iterates over the prophecies with the given status in creation height order and
+// calls the callback on each of them, stopping when the callback returns true
+func (k Keeper) IteratePropheciesByStatus(
+	ctx sdk.Context, status types.StatusText, cb func(prophecy types.Prophecy) (stop bool),
+) {
+	k.iterateIndex(ctx, types.ProphecyStatusIndexByStatusKey(status), nil, types.SplitProphecyStatusIndexKey, cb)
+}
+
+// IteratePropheciesByCreationHeight iterates over the prophecies created from startHeight up to but excluding
+// endHeight in creation height order and calls the callback on each of them, stopping when the callback returns
+// true
+func (k Keeper) IteratePropheciesByCreationHeight(
+	ctx sdk.Context, startHeight, endHeight int64, cb func(prophecy types.Prophecy) (stop bool),
+) {
+	k.iterateIndex(ctx,
+		types.ProphecyCreationHeightIndexByHeightKey(startHeight), types.ProphecyCreationHeightIndexByHeightKey(endHeight),
+		types.SplitProphecyCreationHeightIndexKey, cb,
+	)
+}
+
+// iterateIndex calls the callback on the prophecies of the index entries in the given range. When end is nil, all
+// entries with the start prefix are iterated over. The ids are collected first so that the callback may update
+// the prophecies, and thereby the index, it is called on.
+func (k Keeper) iterateIndex(
+	ctx sdk.Context, start, end []byte, splitKey func(key []byte) string, cb func(prophecy types.Prophecy) (stop bool),
+) {
 	store := ctx.KVStore(k.storeKey)
-	serializedProphecy, err := prophecy.SerializeForDB()
-	if err != nil {
-		panic(err)
+	var iter sdk.Iterator
+	if end == nil {
+		iter = sdk.KVStorePrefixIterator(store, start)
+	} else {
+		iter = store.Iterator(start, end)
+	}
+	var ids []string
+	for ; iter.Valid(); iter.Next() {
+		ids = append(ids, splitKey(iter.Key()))
+	}
+	iter.Close()
+
+	for _, id := range ids {
+		prophecy, found := k.getProphecy(ctx, id)
+		if !found {
+			panic(fmt.Sprintf("prophecy %s is indexed but not stored", id))
+		}
+		if cb(prophecy) {
+			break
+		}
+	}
+}
+
+// SetProphecy saves a prophecy with its validator set snapshot, claims, commits, rejections and past rounds, and
+// updates its tallies and index entries. Votes and past rounds are only removed from a prophecy when it is reopened,
+// so the stored entries of the validators and rounds that are not in the prophecy's lists are left untouched.
+func (k Keeper) SetProphecy(ctx sdk.Context, prophecy types.Prophecy) {
+	k.setDBProphecy(ctx, prophecy.ToDBProphecy())
+	for _, validatorPower := range prophecy.ValidatorPowers {
+		k.setValidatorPower(ctx, prophecy.ScopedID(), validatorPower)
+	}
+	for _, claim := range prophecy.Claims {
+		k.setClaim(ctx, prophecy.ScopedID(), claim)
+		k.setReferences(ctx, prophecy.Namespace, prophecy.ScopedID(), claim.Content)
+	}
+	for _, commit := range prophecy.Commits {
+		k.setCommit(ctx, prophecy.ScopedID(), commit)
+	}
+	for _, validator := range prophecy.Rejections {
+		k.setRejection(ctx, prophecy.ScopedID(), validator)
+	}
+	for _, pastRound := range prophecy.PastRounds {
+		k.setPastRound(ctx, prophecy.ScopedID(), pastRound)
+		for _, claim := range pastRound.Claims {
+			k.setReferences(ctx, prophecy.Namespace, prophecy.ScopedID(), claim.Content)
+		}
+	}
+	k.setReferences(ctx, prophecy.Namespace, prophecy.ScopedID(), prophecy.Status.FinalClaim)
+}
+
+// setDBProphecy saves a prophecy without its validator powers and claims, and updates its index entries
+func (k Keeper) setDBProphecy(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	store := ctx.KVStore(k.storeKey)
+	id := dbProphecy.ScopedID()
+	if oldProphecy, found := k.getDBProphecy(ctx, id); found {
+		store.Delete(types.ProphecyStatusIndexKey(oldProphecy.Status.Text, oldProphecy.CreationHeight, id))
+		store.Delete(types.ProphecyNamespaceStatusIndexKey(oldProphecy.Status.Text, id))
+		store.Delete(types.ProphecyCreationHeightIndexKey(oldProphecy.CreationHeight, id))
+	}
+
+	store.Set(types.ProphecyKey(id), k.cdc.MustMarshalBinaryBare(dbProphecy))
+	store.Set(types.ProphecyStatusIndexKey(dbProphecy.Status.Text, dbProphecy.CreationHeight, id), []byte{})
+	store.Set(types.ProphecyNamespaceStatusIndexKey(dbProphecy.Status.Text, id), []byte{})
+	store.Set(types.ProphecyCreationHeightIndexKey(dbProphecy.CreationHeight, id), []byte{})
+}
+
+func (k Keeper) hasClaim(ctx sdk.Context, id string, validator sdk.ValAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.ClaimKey(id, validator))
+}
+
+func (k Keeper) setClaim(ctx sdk.Context, id string, claim types.ValidatorClaim) {
+	ctx.KVStore(k.storeKey).Set(types.ClaimKey(id, claim.Validator), k.cdc.MustMarshalBinaryBare(claim))
+}
+
+// getCommits returns the claim commitments made on the prophecy with the given id in validator address order
+func (k Keeper) getCommits(ctx sdk.Context, id string) []types.ValidatorCommit {
+	var commits []types.ValidatorCommit
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.CommitsKey(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		commits = append(commits, types.NewValidatorCommit(types.SplitCommitKey(iter.Key()), iter.Value()))
 	}
+	return commits
+}
+
+func (k Keeper) getCommit(ctx sdk.Context, id string, validator sdk.ValAddress) ([]byte, bool) {
+	commitment := ctx.KVStore(k.storeKey).Get(types.CommitKey(id, validator))
+	return commitment, commitment != nil
+}
+
+func (k Keeper) hasCommit(ctx sdk.Context, id string, validator sdk.ValAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.CommitKey(id, validator))
+}
 
-	store.Set([]byte(prophecy.ID), k.cdc.MustMarshalBinaryBare(serializedProphecy))
+func (k Keeper) setCommit(ctx sdk.Context, id string, commit types.ValidatorCommit) {
+	ctx.KVStore(k.storeKey).Set(types.CommitKey(id, commit.Validator), commit.Commitment)
+}
+
+// getRejections returns the validators that rejected the prophecy with the given id in validator address order
+func (k Keeper) getRejections(ctx sdk.Context, id string) []sdk.ValAddress {
+	var rejections []sdk.ValAddress
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.RejectionsKey(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		rejections = append(rejections, types.SplitRejectionKey(iter.Key()))
+	}
+	return rejections
+}
+
+// GetPropheciesByReference returns the prophecies of the namespace, with their past rounds, on which a claim carrying
+// the given reference was made or whose final claim carries it. References are only indexed for the namespaces
+// whose claim content type is an IndexedClaimContentType.
+func (k Keeper) GetPropheciesByReference(ctx sdk.Context, namespace, reference string) []types.Prophecy {
+	var ids []string
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ReferencesKey(namespace, reference))
+	for ; iter.Valid(); iter.Next() {
+		ids = append(ids, types.SplitReferenceKey(iter.Key()))
+	}
+	iter.Close()
+
+	var prophecies []types.Prophecy
+	for _, id := range ids {
+		prophecy, found := k.getProphecy(ctx, id)
+		if !found {
+			panic(fmt.Sprintf("prophecy %s is indexed but not stored", id))
+		}
+		prophecy.PastRounds = k.getPastRounds(ctx, id)
+		prophecies = append(prophecies, prophecy)
+	}
+	return prophecies
+}
+
+// contentReferences returns the references carried by a claim content of the namespace, if its claim content type
+// indexes them
+func (k Keeper) contentReferences(namespace, content string) []string {
+	contentType, ok := k.namespaces[namespace].(types.IndexedClaimContentType)
+	if !ok || content == "" {
+		return nil
+	}
+	return contentType.ContentReferences(content)
+}
+
+// setReferences indexes the prophecy with the given id by the references carried by a claim content
+func (k Keeper) setReferences(ctx sdk.Context, namespace, id, content string) {
+	for _, reference := range k.contentReferences(namespace, content) {
+		ctx.KVStore(k.storeKey).Set(types.ReferenceKey(namespace, reference, id), []byte{})
+	}
+}
+
+// getPastRounds returns the past rounds of the prophecy with the given id in round order
+func (k Keeper) getPastRounds(ctx sdk.Context, id string) []types.ProphecyRound {
+	var pastRounds []types.ProphecyRound
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.PastRoundsKey(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var pastRound types.ProphecyRound
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &pastRound)
+		pastRounds = append(pastRounds, pastRound)
+	}
+	return pastRounds
+}
+
+func (k Keeper) setPastRound(ctx sdk.Context, id string, pastRound types.ProphecyRound) {
+	ctx.KVStore(k.storeKey).Set(types.PastRoundKey(id, pastRound.Round), k.cdc.MustMarshalBinaryBare(pastRound))
+}
+
+// deleteVotes removes the validator set snapshot, claims, commits and rejections of the prophecy with the given id
+func (k Keeper) deleteVotes(ctx sdk.Context, id string) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	for _, prefix := range [][]byte{
+		types.ValidatorPowersKey(id), types.ClaimsKey(id), types.CommitsKey(id), types.RejectionsKey(id),
+	} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		iter.Close()
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) hasRejection(ctx sdk.Context, id string, validator sdk.ValAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.RejectionKey(id, validator))
+}
+
+func (k Keeper) setRejection(ctx sdk.Context, id string, validator sdk.ValAddress) {
+	ctx.KVStore(k.storeKey).Set(types.RejectionKey(id, validator), []byte{})
 }
 
 // ProcessClaim ...
 func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Status, error) {
-	activeValidator := k.checkActiveValidator(ctx, claim.ValidatorAddress)
-	if !activeValidator {
-		return types.Status{}, types.ErrInvalidValidator
+	content, err := k.validateClaim(ctx, claim.Namespace, claim.ID, claim.ValidatorAddress, claim.Content)
+	if err != nil {
+		return types.Status{}, err
 	}
 
-	if claim.ID == "" {
-		return types.Status{}, types.ErrInvalidIdentifier
+	id := types.ScopedProphecyID(claim.Namespace, claim.ID)
+	dbProphecy, found := k.getDBProphecy(ctx, id)
+	switch {
+	case !found || k.canReopen(ctx, dbProphecy):
+		if k.GetParams(ctx).CommitWindowBlocks > 0 {
+			return types.Status{}, types.ErrCommitRequired
+		}
+		dbProphecy = k.newRound(ctx, claim.Namespace, claim.ID, 0)
+	case dbProphecy.CommitEndHeight > 0:
+		return types.Status{}, types.ErrCommitRequired
 	}
 
-	if claim.Content == "" {
-		return types.Status{}, types.ErrInvalidClaim
+	return k.addClaim(ctx, dbProphecy, claim.ValidatorAddress, content)
+}
+
+// ProcessCommit processes the commitment of a validator to the claim it will reveal on a prophecy. The first
+// commitment on a prophecy creates it with a commit window of CommitWindowBlocks, commitments are only accepted
+// until the window closes.
+func (k Keeper) ProcessCommit(ctx sdk.Context, commit types.ClaimCommit) (types.Status, error) {
+	if _, err := k.validateClaim(ctx, commit.Namespace, commit.ID, commit.ValidatorAddress, ""); err != nil {
+		return types.Status{}, err
+	}
+	if len(commit.Commitment) != types.CommitmentLength {
+		return types.Status{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "commitment must be %d bytes long",
+			types.CommitmentLength)
+	}
+
+	id := types.ScopedProphecyID(commit.Namespace, commit.ID)
+	dbProphecy, found := k.getDBProphecy(ctx, id)
+	if !found || k.canReopen(ctx, dbProphecy) {
+		commitWindowBlocks := k.GetParams(ctx).CommitWindowBlocks
+		if commitWindowBlocks == 0 {
+			return types.Status{}, sdkerrors.Wrap(types.ErrCommitWindowClosed, "commit-reveal voting is disabled")
+		}
+		dbProphecy = k.newRound(ctx, commit.Namespace, commit.ID, commitWindowBlocks)
+	}
+
+	if dbProphecy.Status.Text != types.PendingStatusText {
+		return types.Status{}, types.ErrProphecyFinalized
+	}
+	if ctx.BlockHeight() >= dbProphecy.CommitEndHeight {
+		return types.Status{}, types.ErrCommitWindowClosed
+	}
+
+	dbProphecy, _, err := k.getClaimingValidatorPower(ctx, dbProphecy, commit.ValidatorAddress)
+	if err != nil {
+		return types.Status{}, err
 	}
 
-	prophecy, found := k.GetProphecy(ctx, claim.ID)
+	if k.hasCommit(ctx, id, commit.ValidatorAddress) {
+		return types.Status{}, types.ErrDuplicateMessage
+	}
+
+	k.setCommit(ctx, id, types.NewValidatorCommit(commit.ValidatorAddress, commit.Commitment))
+	k.setDBProphecy(ctx, dbProphecy)
+	return dbProphecy.Status, nil
+}
+
+// RevealClaim processes a claim on a prophecy whose claims are committed before they are revealed. The claim is
+// only tallied if it matches the commitment its validator made with the given salt, once the commit window closed.
+func (k Keeper) RevealClaim(ctx sdk.Context, claim types.Claim, salt string) (types.Status, error) {
+	content, err := k.validateClaim(ctx, claim.Namespace, claim.ID, claim.ValidatorAddress, claim.Content)
+	if err != nil {
+		return types.Status{}, err
+	}
+
+	id := types.ScopedProphecyID(claim.Namespace, claim.ID)
+	dbProphecy, found := k.getDBProphecy(ctx, id)
+	if !found {
+		return types.Status{}, sdkerrors.Wrap(types.ErrProphecyNotFound, claim.ID)
+	}
+	if dbProphecy.CommitEndHeight == 0 {
+		return types.Status{}, sdkerrors.Wrap(types.ErrInvalidReveal, "prophecy has no commit window")
+	}
+	if dbProphecy.Status.Text == types.PendingStatusText && ctx.BlockHeight() < dbProphecy.CommitEndHeight {
+		return types.Status{}, types.ErrRevealTooEarly
+	}
+
+	commitment, found := k.getCommit(ctx, id, claim.ValidatorAddress)
+	if !found {
+		return types.Status{}, sdkerrors.Wrapf(types.ErrInvalidReveal, "validator %s made no commitment",
+			claim.ValidatorAddress)
+	}
+	if !bytes.Equal(commitment, types.ClaimCommitment(salt, claim.ValidatorAddress, claim.Content)) {
+		return types.Status{}, types.ErrInvalidReveal
+	}
+
+	return k.addClaim(ctx, dbProphecy, claim.ValidatorAddress, content)
+}
+
+// canReopen returns whether a claim or commitment on the prophecy opens a new round, which it does once the
+// prophecy failed or expired ReopenCooldownBlocks ago
+func (k Keeper) canReopen(ctx sdk.Context, dbProphecy types.DBProphecy) bool {
+	reopenCooldownBlocks := k.GetParams(ctx).ReopenCooldownBlocks
+	return reopenCooldownBlocks > 0 && types.CanReopen(dbProphecy.Status.Text) &&
+		ctx.BlockHeight() >= dbProphecy.FinalizedHeight+reopenCooldownBlocks
+}
+
+// newRound returns a new pending round of the prophecy with the given namespace and id created at the current
+// height, with a commit window of the given number of blocks unless it is zero. If the prophecy exists, its current
+// round is archived with its claims and rejections, and the votes of the new round start from scratch against a
+// new validator set snapshot.
+func (k Keeper) newRound(ctx sdk.Context, namespace, id string, commitWindowBlocks int64) types.DBProphecy {
+	prophecy := types.NewProphecy(namespace, id)
+	prophecy.CreationHeight = ctx.BlockHeight()
+	if commitWindowBlocks > 0 {
+		prophecy.CommitEndHeight = ctx.BlockHeight() + commitWindowBlocks
+	}
+
+	scopedID := prophecy.ScopedID()
+	previousRound, found := k.getProphecy(ctx, scopedID)
 	if !found {
-		prophecy = types.NewProphecy(claim.ID)
+		return prophecy.ToDBProphecy()
 	}
 
-	switch prophecy.Status.Text {
+	k.flushLiveness(ctx, previousRound.ToDBProphecy())
+	k.setPastRound(ctx, scopedID, types.NewProphecyRound(previousRound))
+	k.deleteVotes(ctx, scopedID)
+	prophecy.Round = previousRound.Round + 1
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeProphecyReopened,
+		sdk.NewAttribute(types.AttributeKeyNamespace, namespace),
+		sdk.NewAttribute(types.AttributeKeyProphecyID, id),
+		sdk.NewAttribute(types.AttributeKeyRound, strconv.FormatUint(prophecy.Round, 10)),
+	))
+	return prophecy.ToDBProphecy()
+}
+
+// validateClaim checks that the validator can claim on a prophecy of the namespace with the given id, and returns
+// the given content normalized by the content type of the namespace. Empty content is not validated.
+func (k Keeper) validateClaim(
+	ctx sdk.Context, namespace, id string, validator sdk.ValAddress, content string,
+) (string, error) {
+	activeValidator := k.checkActiveValidator(ctx, validator)
+	if !activeValidator {
+		return "", types.ErrInvalidValidator
+	}
+
+	contentType, found := k.namespaces[namespace]
+	if !found {
+		return "", sdkerrors.Wrap(types.ErrUnknownNamespace, namespace)
+	}
+
+	if err := types.ValidateProphecyID(namespace, id); err != nil {
+		return "", err
+	}
+
+	if content == "" {
+		return "", nil
+	}
+	content, err := contentType.NormalizeContent(content)
+	if err != nil {
+		return "", sdkerrors.Wrap(types.ErrInvalidClaim, err.Error())
+	}
+	return content, nil
+}
+
+// addClaim adds the claim of a validator to a pending prophecy and tallies it, finalizing the prophecy once it
+// reaches consensus or can no longer reach it
+func (k Keeper) addClaim(
+	ctx sdk.Context, dbProphecy types.DBProphecy, validator sdk.ValAddress, content string,
+) (types.Status, error) {
+	if content == "" {
+		return types.Status{}, types.ErrInvalidClaim
+	}
+
+	switch dbProphecy.Status.Text {
 	case types.PendingStatusText:
 		// continue processing
 	default:
 		return types.Status{}, types.ErrProphecyFinalized
 	}
 
-	if prophecy.ValidatorClaims[claim.ValidatorAddress.String()] != "" {
+	dbProphecy, validatorPower, err := k.getClaimingValidatorPower(ctx, dbProphecy, validator)
+	if err != nil {
+		return types.Status{}, err
+	}
+
+	id := dbProphecy.ScopedID()
+	if k.hasClaim(ctx, id, validator) || k.hasRejection(ctx, id, validator) {
 		return types.Status{}, types.ErrDuplicateMessage
 	}
 
-	prophecy.AddClaim(claim.ValidatorAddress, claim.Content)
-	prophecy = k.processCompletion(ctx, prophecy)
+	k.setClaim(ctx, id, types.NewValidatorClaim(validator, content, ctx.BlockHeight()))
+	k.setReferences(ctx, dbProphecy.Namespace, id, content)
+	dbProphecy.AddClaimPower(content, validatorPower.Power)
+	dbProphecy.RemainingPower = dbProphecy.RemainingPower.SubRaw(validatorPower.Power)
+	return k.tallyVote(ctx, dbProphecy)
+}
+
+// ProcessRejection processes the vote of a validator to reject a pending prophecy instead of claiming on it. The
+// prophecy fails once the power of the validators that rejected it reaches RejectionThreshold of its snapshot
+// power, or once no claim can reach the consensus needed anymore.
+func (k Keeper) ProcessRejection(
+	ctx sdk.Context, namespace, id string, validator sdk.ValAddress,
+) (types.Status, error) {
+	if _, err := k.validateClaim(ctx, namespace, id, validator, ""); err != nil {
+		return types.Status{}, err
+	}
+
+	dbProphecy, found := k.getDBProphecy(ctx, types.ScopedProphecyID(namespace, id))
+	if !found {
+		return types.Status{}, sdkerrors.Wrap(types.ErrProphecyNotFound, id)
+	}
+	if dbProphecy.Status.Text != types.PendingStatusText {
+		return types.Status{}, types.ErrProphecyFinalized
+	}
+
+	dbProphecy, validatorPower, err := k.getClaimingValidatorPower(ctx, dbProphecy, validator)
+	if err != nil {
+		return types.Status{}, err
+	}
+
+	scopedID := dbProphecy.ScopedID()
+	if k.hasClaim(ctx, scopedID, validator) || k.hasRejection(ctx, scopedID, validator) {
+		return types.Status{}, types.ErrDuplicateMessage
+	}
+
+	k.setRejection(ctx, scopedID, validator)
+	dbProphecy.RejectedPower = dbProphecy.GetRejectedPower().AddRaw(validatorPower.Power)
+	dbProphecy.RemainingPower = dbProphecy.RemainingPower.SubRaw(validatorPower.Power)
+	return k.tallyVote(ctx, dbProphecy)
+}
+
+// ResolveProphecy finalizes a prophecy with the given status regardless of its votes, so that governance can
+// resolve prophecies that are stuck or wrong. A pending prophecy can be failed, and any prophecy that has not
+// succeeded can succeed with the given final claim, which the hooks then process as if validators had reached
+// consensus on it. Validators are not held accountable for their votes on resolved prophecies.
+func (k Keeper) ResolveProphecy(ctx sdk.Context, namespace, id string, status types.Status) error {
+	dbProphecy, found := k.getDBProphecy(ctx, types.ScopedProphecyID(namespace, id))
+	if !found {
+		return sdkerrors.Wrap(types.ErrProphecyNotFound, id)
+	}
+
+	switch status.Text {
+	case types.FailedStatusText:
+		if dbProphecy.Status.Text != types.PendingStatusText {
+			return types.ErrProphecyFinalized
+		}
+		status.FinalClaim = ""
+	case types.SuccessStatusText:
+		if dbProphecy.Status.Text == types.SuccessStatusText {
+			return types.ErrProphecyFinalized
+		}
+		if status.FinalClaim == "" {
+			return types.ErrInvalidClaim
+		}
+		contentType, found := k.GetNamespaceContentType(namespace)
+		if !found {
+			return sdkerrors.Wrap(types.ErrUnknownNamespace, namespace)
+		}
+		finalClaim, err := contentType.NormalizeContent(status.FinalClaim)
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrInvalidClaim, err.Error())
+		}
+		status.FinalClaim = finalClaim
+	default:
+		return sdkerrors.Wrap(types.ErrInvalidResolution, status.Text.String())
+	}
+
+	if dbProphecy.Status.Text != types.PendingStatusText {
+		k.flushLiveness(ctx, dbProphecy)
+	}
+	status.Resolved = true
+	dbProphecy.Status = status
+	dbProphecy.FinalizedHeight = ctx.BlockHeight()
+	k.setDBProphecy(ctx, dbProphecy)
+	k.setReferences(ctx, namespace, dbProphecy.ScopedID(), status.FinalClaim)
 
-	k.setProphecy(ctx, prophecy)
-	return prophecy.Status, nil
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeProphecyResolved,
+		sdk.NewAttribute(types.AttributeKeyNamespace, namespace),
+		sdk.NewAttribute(types.AttributeKeyProphecyID, id),
+		sdk.NewAttribute(types.AttributeKeyStatus, status.Text.String()),
+	))
+	return k.callProphecyHooks(ctx, dbProphecy)
+}
+
+// tallyVote stores a prophecy whose tallies were just updated by a vote, finalizing it if it reached consensus, can
+// no longer reach it or was rejected
+func (k Keeper) tallyVote(ctx sdk.Context, dbProphecy types.DBProphecy) (types.Status, error) {
+	dbProphecy = k.processCompletion(ctx, dbProphecy)
+
+	k.setDBProphecy(ctx, dbProphecy)
+	if dbProphecy.Status.Text != types.PendingStatusText {
+		k.afterProphecyFinalized(ctx, dbProphecy)
+		if err := k.callProphecyHooks(ctx, dbProphecy); err != nil {
+			return types.Status{}, err
+		}
+	}
+	return dbProphecy.Status, nil
+}
+
+// getClaimingValidatorPower returns the power of a validator in the snapshot of a prophecy, taking the snapshot
+// first if the prophecy has none. It returns an error if the validator cannot claim on the prophecy.
+func (k Keeper) getClaimingValidatorPower(
+	ctx sdk.Context, dbProphecy types.DBProphecy, validator sdk.ValAddress,
+) (types.DBProphecy, types.ValidatorPower, error) {
+	if !dbProphecy.HasValidatorPowers() {
+		// New prophecies, and those stored before validator sets were snapshotted, get their snapshot now
+		dbProphecy = k.snapshotValidatorPowers(ctx, dbProphecy)
+	}
+
+	validatorPower, ok := k.getValidatorPower(ctx, dbProphecy.ScopedID(), validator)
+	if !ok {
+		return dbProphecy, types.ValidatorPower{}, sdkerrors.Wrapf(types.ErrInvalidValidator,
+			"validator %s was not bonded when prophecy %s was created", validator, dbProphecy.ID)
+	}
+	if validatorPower.Forfeited {
+		return dbProphecy, types.ValidatorPower{}, sdkerrors.Wrapf(types.ErrInvalidValidator,
+			"validator %s left the validator set since prophecy %s was created", validator, dbProphecy.ID)
+	}
+	return dbProphecy, validatorPower, nil
+}
+
+// snapshotValidatorPowers stores the powers of the current bonded validator set as the snapshot of the given
+// prophecy and returns the prophecy with its tallies recomputed against it
+func (k Keeper) snapshotValidatorPowers(ctx sdk.Context, dbProphecy types.DBProphecy) types.DBProphecy {
+	id := dbProphecy.ScopedID()
+	prophecy := dbProphecy.ToProphecy(nil, k.getClaims(ctx, id), k.getCommits(ctx, id), k.getRejections(ctx, id))
+	k.stakeKeeper.IterateLastValidatorPowers(ctx, func(operator sdk.ValAddress, power int64) bool {
+		validatorPower := types.NewValidatorPower(operator, power)
+		prophecy.ValidatorPowers = append(prophecy.ValidatorPowers, validatorPower)
+		k.setValidatorPower(ctx, id, validatorPower)
+		return false
+	})
+	return prophecy.ToDBProphecy()
+}
+
+// ExpireProphecies marks the prophecies that are still pending ProphecyExpiryBlocks after their creation as
+// expired and returns them. At most MaxExpiriesPerBlock prophecies are expired per call to keep the work done
+// in a single block bounded, any others are left pending for the following blocks.
+func (k Keeper) ExpireProphecies(ctx sdk.Context) []types.Prophecy {
+	params := k.GetParams(ctx)
+	cutoffHeight := ctx.BlockHeight() - params.ProphecyExpiryBlocks
+	if cutoffHeight < 0 {
+		return nil
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	var ids []string
+	iter := store.Iterator(
+		types.ProphecyStatusIndexByStatusKey(types.PendingStatusText),
+		types.ProphecyStatusIndexByHeightKey(types.PendingStatusText, cutoffHeight+1),
+	)
+	for ; iter.Valid() && uint64(len(ids)) < params.MaxExpiriesPerBlock; iter.Next() {
+		ids = append(ids, types.SplitProphecyStatusIndexKey(iter.Key()))
+	}
+	iter.Close()
+
+	var expiredProphecies []types.Prophecy
+	for _, id := range ids {
+		prophecy, found := k.getProphecy(ctx, id)
+		if !found {
+			panic(fmt.Sprintf("prophecy %s is indexed but not stored", id))
+		}
+
+		prophecy.Status.Text = types.ExpiredStatusText
+		prophecy.FinalizedHeight = ctx.BlockHeight()
+		k.finalizeProphecyCached(ctx, prophecy.ToDBProphecy())
+		expiredProphecies = append(expiredProphecies, prophecy)
+	}
+
+	return expiredProphecies
+}
+
+// RetallyPendingProphecies re-tallies the pending prophecies if the validator set changed since they were last
+// tallied, so that they can be finalized without waiting for a new claim. Validators of a prophecy's snapshot that
+// left the bonded validator set without claiming forfeit their claim, and their power is no longer counted as
+// possible claim power. At most MaxRetalliesPerBlock prophecies are re-tallied per call to keep the work done in a
+// single block bounded, the following calls carry on where it stopped. It returns the prophecies whose status
+// changed.
+func (k Keeper) RetallyPendingProphecies(ctx sdk.Context) []types.Prophecy {
+	store := ctx.KVStore(k.storeKey)
+	start := store.Get(types.ValidatorSetChangedKey)
+	if start == nil {
+		return nil
+	}
+	if len(start) == 0 {
+		start = types.ProphecyStatusIndexByStatusKey(types.PendingStatusText)
+	}
+
+	maxRetallies := k.GetParams(ctx).MaxRetalliesPerBlock
+	var ids []string
+	iter := store.Iterator(start, sdk.PrefixEndBytes(types.ProphecyStatusIndexByStatusKey(types.PendingStatusText)))
+	for ; iter.Valid() && uint64(len(ids)) < maxRetallies; iter.Next() {
+		ids = append(ids, types.SplitProphecyStatusIndexKey(iter.Key()))
+	}
+	if iter.Valid() {
+		store.Set(types.ValidatorSetChangedKey, iter.Key())
+	} else {
+		store.Delete(types.ValidatorSetChangedKey)
+	}
+	iter.Close()
+
+	var finalizedProphecies []types.Prophecy
+	for _, id := range ids {
+		prophecy, found := k.getProphecy(ctx, id)
+		if !found {
+			panic(fmt.Sprintf("prophecy %s is indexed but not stored", id))
+		}
+		if prophecy, finalized := k.retallyProphecy(ctx, prophecy); finalized {
+			finalizedProphecies = append(finalizedProphecies, prophecy)
+		}
+	}
+
+	return finalizedProphecies
+}
+
+// retallyProphecy re-tallies a pending prophecy against the bonded validator set and returns it along with whether
+// it was finalized
+func (k Keeper) retallyProphecy(ctx sdk.Context, prophecy types.Prophecy) (types.Prophecy, bool) {
+	if len(prophecy.ValidatorPowers) == 0 {
+		return prophecy, false
+	}
+
+	forfeited := false
+	for i, validatorPower := range prophecy.ValidatorPowers {
+		if validatorPower.Forfeited || k.checkActiveValidator(ctx, validatorPower.Validator) {
+			continue
+		}
+		if _, claimed := prophecy.GetClaim(validatorPower.Validator); claimed ||
+			prophecy.HasRejected(validatorPower.Validator) {
+			continue
+		}
+		prophecy.ValidatorPowers[i].Forfeited = true
+		k.setValidatorPower(ctx, prophecy.ScopedID(), prophecy.ValidatorPowers[i])
+		forfeited = true
+	}
+	if !forfeited {
+		return prophecy, false
+	}
+
+	dbProphecy := k.processCompletion(ctx, prophecy.ToDBProphecy())
+	if dbProphecy.Status.Text == types.PendingStatusText {
+		k.setDBProphecy(ctx, dbProphecy)
+		return prophecy, false
+	}
+	dbProphecy = k.finalizeProphecyCached(ctx, dbProphecy)
+	prophecy.Status = dbProphecy.Status
+	return prophecy, true
+}
+
+// afterProphecyFinalized records the misbehaviors of a prophecy that just succeeded, and queues any prophecy that
+// was just finalized to count the claims missed on it. Numeric claims are expected to differ from their median, so
+// they are never misbehaviors.
+func (k Keeper) afterProphecyFinalized(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	if dbProphecy.Status.Text == types.SuccessStatusText &&
+		k.aggregationMode(dbProphecy.Namespace) == types.ExactMatchAggregation {
+		k.handleMisbehaviors(ctx, dbProphecy)
+	}
+	k.queueLiveness(ctx, dbProphecy)
+}
+
+// callProphecyHooks calls the hooks of a prophecy that was just finalized
+func (k Keeper) callProphecyHooks(ctx sdk.Context, dbProphecy types.DBProphecy) error {
+	if k.hooks == nil {
+		return nil
+	}
+
+	if dbProphecy.Status.Text == types.SuccessStatusText {
+		return k.hooks.AfterProphecySucceeded(ctx, dbProphecy.Namespace, dbProphecy.ID, dbProphecy.Status.FinalClaim)
+	}
+	return k.hooks.AfterProphecyFailed(ctx, dbProphecy.Namespace, dbProphecy.ID, dbProphecy.Status.Text)
+}
+
+// finalizeProphecyCached stores a prophecy finalized outside of a transaction, where there is no transaction to
+// revert, and calls its hooks. Hooks that fail must not leave partial state changes behind, so the prophecy is
+// finalized on a cached context whose changes are only written once they succeed, and their error is logged. A
+// successful prophecy whose claim the hooks could not execute fails instead, so that governance can still resolve it
+// once the cause is fixed. It returns the prophecy as stored.
+func (k Keeper) finalizeProphecyCached(ctx sdk.Context, dbProphecy types.DBProphecy) types.DBProphecy {
+	cacheCtx, write := ctx.CacheContext()
+	k.setDBProphecy(cacheCtx, dbProphecy)
+	k.afterProphecyFinalized(cacheCtx, dbProphecy)
+	err := k.callProphecyHooks(cacheCtx, dbProphecy)
+	if err == nil {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		return dbProphecy
+	}
+
+	k.Logger(ctx).Error("prophecy hooks failed", "namespace", dbProphecy.Namespace, "prophecy", dbProphecy.ID,
+		"status", dbProphecy.Status.Text, "error", err)
+	if dbProphecy.Status.Text == types.SuccessStatusText {
+		dbProphecy.Status = types.NewStatus(types.FailedStatusText, "")
+		return k.finalizeProphecyCached(ctx, dbProphecy)
+	}
+	k.setDBProphecy(ctx, dbProphecy)
+	k.afterProphecyFinalized(ctx, dbProphecy)
+	return dbProphecy
 }
 
 func (k Keeper) checkActiveValidator(ctx sdk.Context, validatorAddress sdk.ValAddress) bool {
@@ -124,18 +953,54 @@ func (k Keeper) checkActiveValidator(ctx sdk.Context, validatorAddress sdk.ValAd
 // power to be considered successful, or alternatively,
 // will never be able to become successful due to not enough validation power being
 // left to push it over the threshold required for consensus.
-func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
-	highestClaim, highestClaimPower, totalClaimsPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
-	totalPower := k.stakeKeeper.GetLastTotalPower(ctx)
-	highestConsensusRatio := float64(highestClaimPower) / float64(totalPower.Int64())
-	remainingPossibleClaimPower := totalPower.Int64() - totalClaimsPower
-	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
-	highestPossibleConsensusRatio := float64(highestPossibleClaimPower) / float64(totalPower.Int64())
-	if highestConsensusRatio >= k.consensusNeeded {
-		prophecy.Status.Text = types.SuccessStatusText
-		prophecy.Status.FinalClaim = highestClaim
-	} else if highestPossibleConsensusRatio < k.consensusNeeded {
-		prophecy.Status.Text = types.FailedStatusText
-	}
-	return prophecy
+// Prophecies of numeric claims are instead aggregated on their weighted median, which they succeed on once the
+// power of all their claims reaches the threshold.
+// It only uses the running tallies of the prophecy, so its cost does not depend on the size of the validator set.
+func (k Keeper) processCompletion(ctx sdk.Context, dbProphecy types.DBProphecy) types.DBProphecy {
+	finalClaim, finalClaimPower, totalClaimsPower := dbProphecy.FindHighestClaim()
+	if k.aggregationMode(dbProphecy.Namespace) == types.WeightedMedianAggregation {
+		var err error
+		if finalClaim, totalClaimsPower, err = dbProphecy.FindWeightedMedianClaim(); err != nil {
+			panic(fmt.Sprintf("prophecy %s has a non-numeric claim: %s", dbProphecy.ID, err))
+		}
+		finalClaimPower = totalClaimsPower
+	}
+
+	consensusNeeded := k.GetConsensusNeeded(ctx)
+	switch tallyStatus(finalClaimPower, dbProphecy.RemainingPower, dbProphecy.TotalPower, consensusNeeded) {
+	case types.SuccessStatusText:
+		dbProphecy.Status.Text = types.SuccessStatusText
+		dbProphecy.Status.FinalClaim = finalClaim
+	case types.FailedStatusText:
+		dbProphecy.Status.Text = types.FailedStatusText
+	default:
+		rejectedPower := dbProphecy.GetRejectedPower()
+		if rejectedPower.IsPositive() &&
+			sdk.NewDecFromInt(rejectedPower).GTE(k.GetRejectionThreshold(ctx).MulInt(dbProphecy.TotalPower)) {
+			dbProphecy.Status.Text = types.FailedStatusText
+		}
+	}
+	if dbProphecy.Status.Text != types.PendingStatusText {
+		dbProphecy.FinalizedHeight = ctx.BlockHeight()
+	}
+	return dbProphecy
+}
+
+// tallyStatus decides the status of a prophecy from its claimed powers using only integer and fixed-point
+// arithmetic, so that every validator reaches the same result regardless of architecture or compiler.
+// Rather than dividing powers into a ratio, the required power is computed as consensusNeeded * totalPower
+// and compared against the claimed power directly, which keeps comparisons on the threshold exact.
+// remainingClaimPower is the power of the validators that can still make a claim.
+func tallyStatus(highestClaimPower, remainingClaimPower, totalPower sdk.Int, consensusNeeded sdk.Dec) types.StatusText {
+	requiredPower := consensusNeeded.MulInt(totalPower)
+	if sdk.NewDecFromInt(highestClaimPower).GTE(requiredPower) {
+		return types.SuccessStatusText
+	}
+
+	highestPossibleClaimPower := highestClaimPower.Add(remainingClaimPower)
+	if sdk.NewDecFromInt(highestPossibleClaimPower).LT(requiredPower) {
+		return types.FailedStatusText
+	}
+
+	return types.PendingStatusText
 }
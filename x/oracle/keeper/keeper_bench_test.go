@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// Gas bounds of the claims of a prophecy, which grow linearly with the number of validators. The first claim stores
+// the validator set snapshot, the finalizing claim reads the claims made before it.
+const (
+	maxFirstClaimGas                  = 60000
+	maxFirstClaimGasPerValidator      = 3000
+	maxFinalizingClaimGas             = 60000
+	maxFinalizingClaimGasPerValidator = 150
+)
+
+var claimGasValidators = []int{10, 100, 1000}
+
+// claimGasInput returns a test input whose validators all need to claim on a prophecy for it to succeed, and a
+// function returning the gas a claim consumes without storing it
+func claimGasInput(t testing.TB, numValidators int) (TestInput, func(ctx sdk.Context, claim types.Claim) sdk.Gas) {
+	validatorAmounts := make([]int64, numValidators)
+	for i := range validatorAmounts {
+		validatorAmounts[i] = 10
+	}
+	input := CreateTestInput(t, 1, validatorAmounts, "")
+
+	claimGas := func(ctx sdk.Context, claim types.Claim) sdk.Gas {
+		ctx, _ = ctx.CacheContext()
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := input.OracleKeeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed()
+	}
+	return input, claimGas
+}
+
+// makeClaims makes the claims of all validators but the last on the prophecy with the given id
+func makeClaims(t testing.TB, input TestInput, id string) {
+	for _, validator := range input.ValidatorAddresses[:len(input.ValidatorAddresses)-1] {
+		_, err := input.OracleKeeper.ProcessClaim(input.Ctx, types.NewClaim(TestNamespace, id, validator, TestString))
+		require.NoError(t, err)
+	}
+}
+
+func TestProcessClaimGas(t *testing.T) {
+	for _, numValidators := range claimGasValidators {
+		t.Run(fmt.Sprintf("validators=%d", numValidators), func(t *testing.T) {
+			input, claimGas := claimGasInput(t, numValidators)
+			validatorAddresses := input.ValidatorAddresses
+
+			gas := claimGas(input.Ctx, types.NewClaim(TestNamespace, TestID, validatorAddresses[0], TestString))
+			require.LessOrEqual(t, gas, uint64(maxFirstClaimGas+maxFirstClaimGasPerValidator*numValidators))
+
+			makeClaims(t, input, TestID)
+			finalizingClaim := types.NewClaim(TestNamespace, TestID, validatorAddresses[numValidators-1], TestString)
+			gas = claimGas(input.Ctx, finalizingClaim)
+			require.LessOrEqual(t, gas,
+				uint64(maxFinalizingClaimGas+maxFinalizingClaimGasPerValidator*numValidators))
+
+			status, err := input.OracleKeeper.ProcessClaim(input.Ctx, finalizingClaim)
+			require.NoError(t, err)
+			require.Equal(t, types.SuccessStatusText, status.Text)
+		})
+	}
+}
+
+// BenchmarkProcessClaim measures the first claim on a prophecy, which snapshots the validator set, and the claim
+// that makes it succeed, for growing validator sets. The gas/op metric reports the store gas the claim consumes.
+func BenchmarkProcessClaim(b *testing.B) {
+	for _, numValidators := range claimGasValidators {
+		input, claimGas := claimGasInput(b, numValidators)
+		validatorAddresses := input.ValidatorAddresses
+
+		b.Run(fmt.Sprintf("validators=%d/claim=first", numValidators), func(b *testing.B) {
+			claim := types.NewClaim(TestNamespace, TestID, validatorAddresses[0], TestString)
+			var gasConsumed sdk.Gas
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				gasConsumed += claimGas(input.Ctx, claim)
+			}
+			b.ReportMetric(float64(gasConsumed)/float64(b.N), "gas/op")
+		})
+
+		makeClaims(b, input, AlternateTestID)
+		b.Run(fmt.Sprintf("validators=%d/claim=finalizing", numValidators), func(b *testing.B) {
+			claim := types.NewClaim(TestNamespace, AlternateTestID, validatorAddresses[numValidators-1], TestString)
+			var gasConsumed sdk.Gas
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				gasConsumed += claimGas(input.Ctx, claim)
+			}
+			b.ReportMetric(float64(gasConsumed)/float64(b.N), "gas/op")
+		})
+	}
+}
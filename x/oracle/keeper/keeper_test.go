@@ -1,9 +1,12 @@
 package keeper
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/sifchain/peggy/x/oracle/types"
 	"github.com/stretchr/testify/require"
 )
@@ -14,28 +17,195 @@ func TestCreateGetProphecy(t *testing.T) {
 	validator1Pow3 := validatorAddresses[0]
 
 	//Test normal Creation
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test bad Creation with blank id
-	oracleClaim = types.NewClaim("", validator1Pow3, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, "", validator1Pow3, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 
+	//Test bad Creation with an id colliding with the bookkeeping keys
+	oracleClaim = types.NewClaim(TestNamespace, "\x01"+TestID, validator1Pow3, TestString)
+	_, err = keeper.ProcessClaim(ctx, oracleClaim)
+	require.True(t, types.ErrInvalidIdentifier.Is(err))
+
 	//Test bad Creation with blank claim
-	oracleClaim = types.NewClaim(TestID, validator1Pow3, "")
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator1Pow3, "")
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 
 	//Test retrieval
-	prophecy, found := keeper.GetProphecy(ctx, TestID)
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
 	require.True(t, found)
 	require.Equal(t, prophecy.ID, TestID)
 	require.Equal(t, prophecy.Status.Text, types.PendingStatusText)
-	require.Equal(t, prophecy.ClaimValidators[TestString][0], validator1Pow3)
-	require.Equal(t, prophecy.ValidatorClaims[validator1Pow3.String()], TestString)
+	require.Equal(t, []types.ValidatorClaim{types.NewValidatorClaim(validator1Pow3, TestString, ctx.BlockHeight())},
+		prophecy.Claims)
+	claim, ok := prophecy.GetClaim(validator1Pow3)
+	require.True(t, ok)
+	require.Equal(t, TestString, claim)
+}
+
+// lowerCaseContentType is a claim content type whose claims are equal whatever their case
+type lowerCaseContentType struct {
+	types.StringContentType
+}
+
+func (lowerCaseContentType) NormalizeContent(content string) (string, error) {
+	if content == "" {
+		return "", types.ErrInvalidClaim
+	}
+	return strings.ToLower(content), nil
+}
+
+// referencedContentType is a claim content type whose claims are "reference:value" pairs, indexed by reference
+type referencedContentType struct {
+	types.StringContentType
+}
+
+func (referencedContentType) ContentReferences(content string) []string {
+	return []string{strings.SplitN(content, ":", 2)[0]}
+}
+
+func TestGetPropheciesByReference(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	keeper.RegisterNamespace("referenced", referencedContentType{})
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow3 := validatorAddresses[1]
+
+	for _, claim := range []types.Claim{
+		types.NewClaim("referenced", TestID, validator1Pow3, "tx1:first"),
+		types.NewClaim("referenced", TestID, validator2Pow3, "tx2:first"),
+		types.NewClaim("referenced", AlternateTestID, validator1Pow3, "tx1:second"),
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, "tx1:first"),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+
+	// prophecies are indexed by the references of all their claims, only in namespaces that index them
+	prophecies := keeper.GetPropheciesByReference(ctx, "referenced", "tx1")
+	require.Len(t, prophecies, 2)
+	require.Equal(t, AlternateTestID, prophecies[0].ID)
+	require.Equal(t, TestID, prophecies[1].ID)
+	prophecies = keeper.GetPropheciesByReference(ctx, "referenced", "tx2")
+	require.Len(t, prophecies, 1)
+	require.Equal(t, TestID, prophecies[0].ID)
+	require.Empty(t, keeper.GetPropheciesByReference(ctx, "referenced", "tx3"))
+	require.Empty(t, keeper.GetPropheciesByReference(ctx, TestNamespace, "tx1"))
+
+	// and by the reference of their final claim when they are resolved
+	err := keeper.ResolveProphecy(ctx, "referenced", AlternateTestID,
+		types.NewStatus(types.SuccessStatusText, "tx3:second"))
+	require.NoError(t, err)
+	prophecies = keeper.GetPropheciesByReference(ctx, "referenced", "tx3")
+	require.Len(t, prophecies, 1)
+	require.Equal(t, AlternateTestID, prophecies[0].ID)
+}
+
+func TestProcessClaimNamespaces(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.8, []int64{3, 7}, "")
+	keeper.RegisterNamespace("lower", lowerCaseContentType{})
+	require.Panics(t, func() { keeper.RegisterNamespace("lower", types.StringContentType{}) })
+	require.Panics(t, func() { keeper.RegisterNamespace("Invalid/Namespace", types.StringContentType{}) })
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow7 := validatorAddresses[1]
+
+	// claims of unregistered namespaces are rejected
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim("unknown", TestID, validator1Pow3, TestString))
+	require.True(t, types.ErrUnknownNamespace.Is(err))
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim("", TestID, validator1Pow3, TestString))
+	require.True(t, types.ErrUnknownNamespace.Is(err))
+
+	// the same id names distinct prophecies in distinct namespaces
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, "Value"))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim("lower", TestID, validator1Pow3, "Value"))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+
+	// claims are tallied by their normalized content
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow7, "value"))
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, status.Text)
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim("lower", TestID, validator2Pow7, "VALUE"))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.Equal(t, "value", status.FinalClaim)
+
+	prophecy, found := keeper.GetProphecy(ctx, "lower", TestID)
+	require.True(t, found)
+	require.Equal(t, "lower", prophecy.Namespace)
+	claim, _ := prophecy.GetClaim(validator1Pow3)
+	require.Equal(t, "value", claim)
+	prophecy, found = keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+}
+
+func TestWeightedMedianAggregation(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{1, 2, 3, 4}, "")
+	keeper.RegisterNamespace("price", types.DecimalContentType{})
+
+	validator1Pow1 := validatorAddresses[0]
+	validator2Pow2 := validatorAddresses[1]
+	validator4Pow4 := validatorAddresses[3]
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim("price", TestID, validator1Pow1, "ten"))
+	require.True(t, types.ErrInvalidClaim.Is(err))
+
+	// numeric claims are pending until the power of all claims made reaches the consensus needed
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim("price", TestID, validator1Pow1, "10"))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim("price", TestID, validator2Pow2, "12.5"))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+
+	// and then succeed on their power-weighted median: 10 (1), 11 (4), 12.5 (2)
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim("price", TestID, validator4Pow4, "11.0"))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.Equal(t, sdk.NewDec(11).String(), status.FinalClaim)
+
+	// claims that differ from the median are not misbehaviors
+	require.Empty(t, keeper.GetMisbehaviors(ctx))
+
+	prophecy, found := keeper.GetProphecy(ctx, "price", TestID)
+	require.True(t, found)
+	claim, _ := prophecy.GetClaim(validator2Pow2)
+	require.Equal(t, sdk.MustNewDecFromStr("12.5").String(), claim)
+}
+
+func TestFindWeightedMedianClaim(t *testing.T) {
+	testCases := []struct {
+		claimPowers []int64
+		claims      []string
+		median      string
+	}{
+		{[]int64{1}, []string{"5"}, "5"},
+		{[]int64{1, 1}, []string{"5", "7"}, "5"},
+		{[]int64{1, 2}, []string{"5", "7"}, "7"},
+		{[]int64{3, 1, 1, 1}, []string{"9", "1", "2", "3"}, "3"},
+		{[]int64{4, 1, 1, 1}, []string{"9", "1", "2", "3"}, "9"},
+		{[]int64{5, 5}, []string{"-1", "10"}, "-1"},
+	}
+
+	for _, tc := range testCases {
+		var dbProphecy types.DBProphecy
+		for i, claim := range tc.claims {
+			dbProphecy.AddClaimPower(sdk.MustNewDecFromStr(claim).String(), tc.claimPowers[i])
+		}
+		median, _, err := dbProphecy.FindWeightedMedianClaim()
+		require.NoError(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr(tc.median).String(), median, tc.claims)
+	}
 }
 
 func TestBadConsensusForOracle(t *testing.T) {
@@ -54,26 +224,26 @@ func TestBadMsgs(t *testing.T) {
 	validator1Pow3 := validatorAddresses[0]
 
 	//Test empty claim
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, "")
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, "")
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.Equal(t, status.FinalClaim, "")
 	require.True(t, strings.Contains(err.Error(), "claim cannot be empty string"))
 
 	//Test normal Creation
-	oracleClaim = types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test duplicate message
-	oracleClaim = types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "already processed message from validator for this id"))
 
 	//Test second but non duplicate message
-	oracleClaim = types.NewClaim(TestID, validator1Pow3, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator1Pow3, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "already processed message from validator for this id"))
@@ -87,20 +257,20 @@ func TestSuccessfulProphecy(t *testing.T) {
 	validator3Pow4 := validatorAddresses[2]
 
 	//Test first claim
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test second claim completes and finalizes to success
-	oracleClaim = types.NewClaim(TestID, validator2Pow3, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow3, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
 	require.Equal(t, status.FinalClaim, TestString)
 
 	//Test third claim not possible
-	oracleClaim = types.NewClaim(TestID, validator3Pow4, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "prophecy already finalized"))
@@ -114,19 +284,19 @@ func TestSuccessfulProphecyWithDisagreement(t *testing.T) {
 	validator3Pow4 := validatorAddresses[2]
 
 	//Test first claim
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test second disagreeing claim processed fine
-	oracleClaim = types.NewClaim(TestID, validator2Pow3, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow3, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test third claim agrees and finalizes to success
-	oracleClaim = types.NewClaim(TestID, validator3Pow4, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
@@ -141,26 +311,140 @@ func TestFailedProphecy(t *testing.T) {
 	validator3Pow4 := validatorAddresses[2]
 
 	//Test first claim
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test second disagreeing claim processed fine
-	oracleClaim = types.NewClaim(TestID, validator2Pow3, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow3, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 	require.Equal(t, status.FinalClaim, "")
 
 	//Test third disagreeing claim processed fine and prophecy fails
-	oracleClaim = types.NewClaim(TestID, validator3Pow4, AnotherAlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator3Pow4, AnotherAlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.FailedStatusText)
 	require.Equal(t, status.FinalClaim, "")
 }
 
+func TestProcessRejection(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{2, 3, 5}, "")
+
+	validator1Pow2 := validatorAddresses[0]
+	validator2Pow3 := validatorAddresses[1]
+	validator3Pow5 := validatorAddresses[2]
+
+	// only existing prophecies can be rejected
+	_, err := keeper.ProcessRejection(ctx, TestNamespace, TestID, validator2Pow3)
+	require.True(t, types.ErrProphecyNotFound.Is(err))
+
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow2, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	_, err = keeper.ProcessRejection(ctx, TestNamespace, TestID, validator1Pow2)
+	require.True(t, types.ErrDuplicateMessage.Is(err))
+
+	// rejections below the rejection threshold leave the prophecy pending
+	status, err = keeper.ProcessRejection(ctx, TestNamespace, TestID, validator2Pow3)
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow3, TestString))
+	require.True(t, types.ErrDuplicateMessage.Is(err))
+
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator3Pow5, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, []sdk.ValAddress{validator2Pow3}, prophecy.Rejections)
+	require.True(t, prophecy.ToDBProphecy().RejectedPower.Equal(sdk.NewInt(3)))
+
+	// rejecting is not missing a claim
+	require.False(t, keeper.GetMissedClaim(ctx, validator2Pow3, 0))
+
+	// a prophecy fails as soon as the rejection threshold is reached
+	params := keeper.GetParams(ctx)
+	params.RejectionThreshold = sdk.NewDecWithPrec(3, 1)
+	keeper.SetParams(ctx, params)
+
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, AlternateTestID, validator1Pow2, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+	status, err = keeper.ProcessRejection(ctx, TestNamespace, AlternateTestID, validator2Pow3)
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, status.Text)
+
+	_, err = keeper.ProcessRejection(ctx, TestNamespace, AlternateTestID, validator3Pow5)
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+}
+
+func TestReopenProphecy(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{2, 4, 4}, "")
+
+	validator1Pow2 := validatorAddresses[0]
+	validator2Pow4 := validatorAddresses[1]
+	validator3Pow4 := validatorAddresses[2]
+
+	ctx = ctx.WithBlockHeight(10)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow2, TestString))
+	require.NoError(t, err)
+	status, err := keeper.ProcessRejection(ctx.WithBlockHeight(11), TestNamespace, TestID, validator2Pow4)
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, status.Text)
+
+	// failed prophecies stay failed while reopening is disabled
+	claim := types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString)
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(20), claim)
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+
+	params := keeper.GetParams(ctx)
+	params.ReopenCooldownBlocks = 5
+	keeper.SetParams(ctx, params)
+
+	// and until the cooldown has passed
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(15), claim)
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+
+	ctx = ctx.WithBlockHeight(16)
+	status, err = keeper.ProcessClaim(ctx, claim)
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, uint64(1), prophecy.Round)
+	require.Equal(t, int64(16), prophecy.CreationHeight)
+	require.Equal(t, int64(0), prophecy.FinalizedHeight)
+	require.Len(t, prophecy.Claims, 1)
+	require.Empty(t, prophecy.Rejections)
+
+	// the votes of the previous round are kept for audit
+	require.Len(t, prophecy.PastRounds, 1)
+	pastRound := prophecy.PastRounds[0]
+	require.Equal(t, uint64(0), pastRound.Round)
+	require.Equal(t, types.FailedStatusText, pastRound.Status.Text)
+	require.Equal(t, int64(10), pastRound.CreationHeight)
+	require.Equal(t, int64(11), pastRound.FinalizedHeight)
+	require.Len(t, pastRound.Claims, 1)
+	require.Equal(t, validator1Pow2, pastRound.Claims[0].Validator)
+	require.Equal(t, []sdk.ValAddress{validator2Pow4}, pastRound.Rejections)
+
+	// validators of the previous round vote again in the new one
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow4, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+
+	// successful prophecies are never reopened
+	claim = types.NewClaim(TestNamespace, TestID, validator1Pow2, TestString)
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(30), claim)
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+}
+
 func TestPowerOverrule(t *testing.T) {
 	//Testing with 2 validators but one has high enough power to overrule
 	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
@@ -169,13 +453,13 @@ func TestPowerOverrule(t *testing.T) {
 	validator2Pow7 := validatorAddresses[1]
 
 	//Test first claim
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test second disagreeing claim processed fine and finalized to its bytes
-	oracleClaim = types.NewClaim(TestID, validator2Pow7, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow7, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
@@ -191,25 +475,25 @@ func TestPowerAternate(t *testing.T) {
 	validator4Pow9 := validatorAddresses[3]
 
 	//Test claim by v1
-	oracleClaim := types.NewClaim(TestID, validator1Pow5, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow5, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test claim by v2
-	oracleClaim = types.NewClaim(TestID, validator2Pow4, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow4, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test alternate claim by v4
-	oracleClaim = types.NewClaim(TestID, validator4Pow9, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator4Pow9, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test finalclaim by v3
-	oracleClaim = types.NewClaim(TestID, validator3Pow3, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator3Pow3, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
@@ -224,27 +508,27 @@ func TestMultipleProphecies(t *testing.T) {
 	validator2Pow7 := validatorAddresses[1]
 
 	//Test claim on first id with first validator
-	oracleClaim := types.NewClaim(TestID, validator1Pow3, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString)
 	status, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.PendingStatusText)
 
 	//Test claim on second id with second validator
-	oracleClaim = types.NewClaim(AlternateTestID, validator2Pow7, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, AlternateTestID, validator2Pow7, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
 	require.Equal(t, status.FinalClaim, AlternateTestString)
 
 	//Test claim on first id with second validator
-	oracleClaim = types.NewClaim(TestID, validator2Pow7, TestString)
+	oracleClaim = types.NewClaim(TestNamespace, TestID, validator2Pow7, TestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.NoError(t, err)
 	require.Equal(t, status.Text, types.SuccessStatusText)
 	require.Equal(t, status.FinalClaim, TestString)
 
 	//Test claim on second id with first validator
-	oracleClaim = types.NewClaim(AlternateTestID, validator1Pow3, AlternateTestString)
+	oracleClaim = types.NewClaim(TestNamespace, AlternateTestID, validator1Pow3, AlternateTestString)
 	status, err = keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "prophecy already finalized"))
@@ -258,8 +542,329 @@ func TestNonValidator(t *testing.T) {
 	inActiveValidatorAddress := testValidatorAddresses[9]
 
 	//Test claim on first id with first validator
-	oracleClaim := types.NewClaim(TestID, inActiveValidatorAddress, TestString)
+	oracleClaim := types.NewClaim(TestNamespace, TestID, inActiveValidatorAddress, TestString)
 	_, err := keeper.ProcessClaim(ctx, oracleClaim)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "claim must be made by actively bonded validator"))
 }
+
+func TestTallyStatus(t *testing.T) {
+	testCases := []struct {
+		name                string
+		highestClaimPower   int64
+		remainingClaimPower int64
+		totalPower          int64
+		consensusNeeded     string
+		expectedStatus      types.StatusText
+	}{
+		{"ratio exactly on threshold succeeds", 7, 3, 10, "0.7", types.SuccessStatusText},
+		{"ratio just below threshold stays pending", 69, 31, 100, "0.7", types.PendingStatusText},
+		{"ratio just above threshold succeeds", 71, 29, 100, "0.7", types.SuccessStatusText},
+		{"highest possible exactly on threshold stays pending", 4, 3, 10, "0.7", types.PendingStatusText},
+		{"highest possible just below threshold fails", 3, 3, 10, "0.71", types.FailedStatusText},
+		{"unanimous consensus on threshold of one", 10, 0, 10, "1", types.SuccessStatusText},
+		{"single missing power fails threshold of one", 9, 0, 10, "1", types.FailedStatusText},
+		{"a third of total power on threshold succeeds", 1, 2, 3, "0.333333333333333333", types.SuccessStatusText},
+		{"two thirds of total power below rounded threshold", 2, 1, 3, "0.666666666666666667",
+			types.PendingStatusText},
+		{"large powers on threshold succeed", 700000000000, 300000000000, 1000000000000, "0.7",
+			types.SuccessStatusText},
+		{"large powers one below threshold stay pending", 699999999999, 300000000001, 1000000000000, "0.7",
+			types.PendingStatusText},
+		{"no claims stays pending", 0, 10, 10, "0.7", types.PendingStatusText},
+		{"unbonded validators no longer count as possible power", 4, 2, 10, "0.7", types.FailedStatusText},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			status := tallyStatus(
+				sdk.NewInt(tc.highestClaimPower), sdk.NewInt(tc.remainingClaimPower), sdk.NewInt(tc.totalPower),
+				sdk.MustNewDecFromStr(tc.consensusNeeded),
+			)
+			require.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func TestExpireProphecies(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	params := keeper.GetParams(ctx)
+	params.ProphecyExpiryBlocks = 10
+	params.MaxExpiriesPerBlock = 2
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow7 := validatorAddresses[1]
+
+	// three pending prophecies and a successful one created at height 1
+	ctx = ctx.WithBlockHeight(1)
+	pendingIDs := []string{"a", "b", "c"}
+	for _, id := range pendingIDs {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, validator1Pow3, TestString))
+		require.NoError(t, err)
+	}
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow7, TestString))
+	require.NoError(t, err)
+
+	prophecy, _ := keeper.GetProphecy(ctx, TestNamespace, "a")
+	require.Equal(t, int64(1), prophecy.CreationHeight)
+
+	// nothing expires before the expiry height
+	require.Empty(t, keeper.ExpireProphecies(ctx.WithBlockHeight(10)))
+
+	// at most MaxExpiriesPerBlock prophecies expire per block
+	expired := keeper.ExpireProphecies(ctx.WithBlockHeight(11))
+	require.Len(t, expired, 2)
+	require.Equal(t, "a", expired[0].ID)
+	require.Equal(t, "b", expired[1].ID)
+	prophecy, _ = keeper.GetProphecy(ctx, TestNamespace, "c")
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+
+	expired = keeper.ExpireProphecies(ctx.WithBlockHeight(12))
+	require.Len(t, expired, 1)
+	require.Equal(t, "c", expired[0].ID)
+	require.Empty(t, keeper.ExpireProphecies(ctx.WithBlockHeight(13)))
+
+	for _, id := range pendingIDs {
+		prophecy, _ = keeper.GetProphecy(ctx, TestNamespace, id)
+		require.Equal(t, types.ExpiredStatusText, prophecy.Status.Text)
+		require.Equal(t, "", prophecy.Status.FinalClaim)
+	}
+	prophecy, _ = keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	// expired prophecies accept no more claims
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, "a", validator2Pow7, TestString))
+	require.True(t, types.ErrProphecyFinalized.Is(err))
+}
+
+func TestProphecyIndexes(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow7 := validatorAddresses[1]
+
+	ctx = ctx.WithBlockHeight(2)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, AlternateTestID, validator1Pow3, TestString))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(1)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.NoError(t, err)
+
+	collectIDs := func(iterate func(cb func(prophecy types.Prophecy) bool)) []string {
+		var ids []string
+		iterate(func(prophecy types.Prophecy) bool {
+			ids = append(ids, prophecy.ID)
+			return false
+		})
+		return ids
+	}
+	byStatus := func(status types.StatusText) []string {
+		return collectIDs(func(cb func(prophecy types.Prophecy) bool) {
+			keeper.IteratePropheciesByStatus(ctx, status, cb)
+		})
+	}
+	byHeight := func(startHeight, endHeight int64) []string {
+		return collectIDs(func(cb func(prophecy types.Prophecy) bool) {
+			keeper.IteratePropheciesByCreationHeight(ctx, startHeight, endHeight, cb)
+		})
+	}
+
+	// indexes are ordered by creation height rather than id
+	require.Equal(t, []string{TestID, AlternateTestID}, byStatus(types.PendingStatusText))
+	require.Empty(t, byStatus(types.SuccessStatusText))
+	require.Equal(t, []string{TestID, AlternateTestID}, byHeight(0, 3))
+	require.Equal(t, []string{AlternateTestID}, byHeight(2, 3))
+	require.Empty(t, byHeight(3, 10))
+
+	// finalizing a prophecy moves it to its new status in the index
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow7, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.Equal(t, []string{AlternateTestID}, byStatus(types.PendingStatusText))
+	require.Equal(t, []string{TestID}, byStatus(types.SuccessStatusText))
+	require.Equal(t, []string{TestID, AlternateTestID}, byHeight(0, 3))
+
+	// claims are stored in validator address order whatever order they were made in
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Len(t, prophecy.Claims, 2)
+	require.True(t, bytes.Compare(prophecy.Claims[0].Validator, prophecy.Claims[1].Validator) < 0)
+}
+
+func TestRunningTallies(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 3, 4, 10}, "")
+
+	claims := []string{TestString, AlternateTestString, TestString}
+	for i, claim := range claims {
+		status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validatorAddresses[i], claim))
+		require.NoError(t, err)
+		require.Equal(t, types.PendingStatusText, status.Text)
+	}
+
+	dbProphecy, found := keeper.getDBProphecy(ctx, types.ScopedProphecyID(TestNamespace, TestID))
+	require.True(t, found)
+	require.Equal(t, []types.ClaimPower{
+		{Claim: TestString, Power: sdk.NewInt(7)},
+		{Claim: AlternateTestString, Power: sdk.NewInt(3)},
+	}, dbProphecy.ClaimPowers)
+	require.Equal(t, sdk.NewInt(20), dbProphecy.TotalPower)
+	require.Equal(t, sdk.NewInt(10), dbProphecy.RemainingPower)
+
+	// the running tallies match the ones computed from the claims and validator powers
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, dbProphecy, prophecy.ToDBProphecy())
+
+	highestClaim, highestClaimPower, totalClaimsPower := prophecy.FindHighestClaim()
+	require.Equal(t, TestString, highestClaim)
+	require.Equal(t, sdk.NewInt(7), highestClaimPower)
+	require.Equal(t, sdk.NewInt(10), totalClaimsPower)
+
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validatorAddresses[3], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	require.Equal(t, TestString, status.FinalClaim)
+}
+
+func TestValidatorPowerSnapshot(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper, stakingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow7 := input.ValidatorAddresses[1]
+
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Len(t, prophecy.ValidatorPowers, 2)
+	require.Equal(t, sdk.NewInt(10), prophecy.TotalPower())
+	power, ok := prophecy.GetValidatorPower(validator2Pow7)
+	require.True(t, ok)
+	require.Equal(t, int64(7), power)
+
+	// a validator bonded after the prophecy was created can neither claim on it nor shift its threshold
+	newValPubKey := CreateTestPubKeys(3)[2]
+	newValAddr := sdk.ValAddress(newValPubKey.Address().Bytes())
+	newValidator := stakingtypes.NewValidator(newValAddr, newValPubKey, stakingtypes.Description{})
+	newValidator, _ = newValidator.AddTokensFromDel(sdk.TokensFromConsensusPower(90))
+	stakingKeeper.SetValidator(ctx, newValidator)
+	stakingKeeper.SetValidatorByConsAddr(ctx, newValidator)
+	stakingKeeper.SetValidatorByPowerIndex(ctx, newValidator)
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, newValAddr, TestString))
+	require.True(t, types.ErrInvalidValidator.Is(err))
+
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow7, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+
+	// new prophecies snapshot the new validator set
+	status, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, AlternateTestID, newValAddr, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+	prophecy, found = keeper.GetProphecy(ctx, TestNamespace, AlternateTestID)
+	require.True(t, found)
+	require.Len(t, prophecy.ValidatorPowers, 3)
+	require.Equal(t, sdk.NewInt(100), prophecy.TotalPower())
+}
+
+func TestRetallyPendingProphecies(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper, stakingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper
+	stakingKeeper.SetHooks(keeper.Hooks())
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow7 := input.ValidatorAddresses[1]
+
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, status.Text)
+
+	// nothing to re-tally while the validator set is unchanged
+	require.Empty(t, keeper.RetallyPendingProphecies(ctx))
+
+	// once the powerful validator is gone, its power can no longer be claimed and the prophecy can not reach
+	// consensus anymore
+	validator2, found := stakingKeeper.GetValidator(ctx, validator2Pow7)
+	require.True(t, found)
+	stakingKeeper.Jail(ctx, validator2.GetConsAddr())
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	finalized := keeper.RetallyPendingProphecies(ctx)
+	require.Len(t, finalized, 1)
+	require.Equal(t, TestID, finalized[0].ID)
+	require.Equal(t, types.FailedStatusText, finalized[0].Status.Text)
+
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, finalized[0], prophecy)
+
+	// the change is only re-tallied once
+	require.Empty(t, keeper.RetallyPendingProphecies(ctx))
+}
+
+func TestRetallyPendingPropheciesPerBlock(t *testing.T) {
+	input := CreateTestInput(t, 0.7, []int64{3, 7}, "")
+	ctx, keeper, stakingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper
+	stakingKeeper.SetHooks(keeper.Hooks())
+
+	params := keeper.GetParams(ctx)
+	params.MaxRetalliesPerBlock = 2
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow7 := input.ValidatorAddresses[1]
+
+	ids := []string{"id1", "id2", "id3"}
+	for _, id := range ids {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, validator1Pow3, TestString))
+		require.NoError(t, err)
+	}
+
+	// staking changes other than validators leaving the bonded set do not affect pending prophecies
+	validator2, found := stakingKeeper.GetValidator(ctx, validator2Pow7)
+	require.True(t, found)
+	delegator := sdk.AccAddress(validator1Pow3)
+	require.NoError(t, input.BankKeeper.SetCoins(ctx, delegator,
+		sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.TokensFromConsensusPower(1)))))
+	_, err := stakingKeeper.Delegate(ctx, delegator, sdk.TokensFromConsensusPower(1), sdk.Unbonded, validator2, true)
+	require.NoError(t, err)
+	require.False(t, ctx.KVStore(keeper.storeKey).Has(types.ValidatorSetChangedKey))
+
+	// at most MaxRetalliesPerBlock prophecies are re-tallied per block, the next block carries on where it stopped
+	stakingKeeper.Jail(ctx, validator2.GetConsAddr())
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+	finalized := keeper.RetallyPendingProphecies(ctx)
+	require.Len(t, finalized, 2)
+	require.Equal(t, ids[0], finalized[0].ID)
+	require.Equal(t, ids[1], finalized[1].ID)
+
+	finalized = keeper.RetallyPendingProphecies(ctx)
+	require.Len(t, finalized, 1)
+	require.Equal(t, ids[2], finalized[0].ID)
+	require.Empty(t, keeper.RetallyPendingProphecies(ctx))
+}
+
+func TestGetParamsNotSet(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 7}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+
+	// chains upgraded in place from a version without some parameters read their default value
+	keeper.paramSpace = input.ParamsKeeper.Subspace("upgraded").WithKeyTable(types.ParamKeyTable())
+	require.Equal(t, types.DefaultParams(), keeper.GetParams(ctx))
+	require.Equal(t, types.DefaultConsensusNeeded, keeper.GetConsensusNeeded(ctx))
+	require.Equal(t, types.DefaultRejectionThreshold, keeper.GetRejectionThreshold(ctx))
+
+	consensusNeeded := sdk.NewDecWithPrec(6, 1)
+	keeper.paramSpace.Set(ctx, types.KeyConsensusNeeded, consensusNeeded)
+	params := types.DefaultParams()
+	params.ConsensusNeeded = consensusNeeded
+	require.Equal(t, params, keeper.GetParams(ctx))
+	require.Equal(t, consensusNeeded, keeper.GetConsensusNeeded(ctx))
+}
@@ -0,0 +1,249 @@
+package keeper
+
+import (
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// GetValidatorLiveness returns the liveness record of a validator, and whether it has one
+func (k Keeper) GetValidatorLiveness(ctx sdk.Context, validator sdk.ValAddress) (types.ValidatorLiveness, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.ValidatorLivenessKey(validator))
+	if bz == nil {
+		return types.ValidatorLiveness{}, false
+	}
+
+	var liveness types.ValidatorLiveness
+	k.cdc.MustUnmarshalBinaryBare(bz, &liveness)
+	return liveness, true
+}
+
+// SetValidatorLiveness stores the liveness record of a validator
+func (k Keeper) SetValidatorLiveness(ctx sdk.Context, liveness types.ValidatorLiveness) {
+	ctx.KVStore(k.storeKey).Set(types.ValidatorLivenessKey(liveness.Validator), k.cdc.MustMarshalBinaryBare(liveness))
+}
+
+// IterateValidatorLiveness iterates over the liveness records in validator address order and calls the callback
+// on each of them, stopping when the callback returns true
+func (k Keeper) IterateValidatorLiveness(ctx sdk.Context, cb func(liveness types.ValidatorLiveness) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ValidatorLivenessKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var liveness types.ValidatorLiveness
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &liveness)
+		if cb(liveness) {
+			break
+		}
+	}
+}
+
+// GetAllValidatorLiveness returns all liveness records in the store
+func (k Keeper) GetAllValidatorLiveness(ctx sdk.Context) []types.ValidatorLiveness {
+	var livenesses []types.ValidatorLiveness
+	k.IterateValidatorLiveness(ctx, func(liveness types.ValidatorLiveness) bool {
+		livenesses = append(livenesses, liveness)
+		return false
+	})
+	return livenesses
+}
+
+// GetMissedClaim returns whether a validator missed the claim at an index of its claim window
+func (k Keeper) GetMissedClaim(ctx sdk.Context, validator sdk.ValAddress, index int64) bool {
+	return ctx.KVStore(k.storeKey).Has(types.MissedClaimKey(validator, index))
+}
+
+// SetMissedClaim records whether a validator missed the claim at an index of its claim window
+func (k Keeper) SetMissedClaim(ctx sdk.Context, validator sdk.ValAddress, index int64, missed bool) {
+	store := ctx.KVStore(k.storeKey)
+	if missed {
+		store.Set(types.MissedClaimKey(validator, index), []byte{})
+	} else {
+		store.Delete(types.MissedClaimKey(validator, index))
+	}
+}
+
+// GetMissedClaims returns the missed claims of all validators, by validator address and window index
+func (k Keeper) GetMissedClaims(ctx sdk.Context) []types.MissedClaim {
+	var missedClaims []types.MissedClaim
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.MissedClaimKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		missedClaims = append(missedClaims, types.NewMissedClaim(types.SplitMissedClaimKey(iter.Key())))
+	}
+	return missedClaims
+}
+
+// clearMissedClaims deletes the claim window of a validator
+func (k Keeper) clearMissedClaims(ctx sdk.Context, validator sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.MissedClaimsByValidatorKey(validator))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// ProcessLivenessQueue counts the finalized prophecies of the liveness queue in the claim windows of the validators
+// of their snapshot, in the order they were finalized, see handleLiveness. At most maxValidators validators are
+// counted per call to keep the work done in a single block bounded, the next call carries on where it stopped. It
+// returns the number of validators counted.
+func (k Keeper) ProcessLivenessQueue(ctx sdk.Context, maxValidators uint64) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	var counted uint64
+	for counted < maxValidators {
+		iter := sdk.KVStorePrefixIterator(store, types.LivenessQueueKeyPrefix)
+		if !iter.Valid() {
+			iter.Close()
+			break
+		}
+		key, start := iter.Key(), iter.Value()
+		iter.Close()
+
+		n, next := k.handleLiveness(ctx, types.SplitLivenessQueueKey(key), start, maxValidators-counted)
+		counted += n
+		if next == nil {
+			store.Delete(key)
+		} else {
+			store.Set(key, next)
+		}
+	}
+	return counted
+}
+
+// queueLiveness queues a prophecy that was just finalized to be counted in the claim windows of its validators
+func (k Keeper) queueLiveness(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	ctx.KVStore(k.storeKey).Set(types.LivenessQueueKey(dbProphecy.FinalizedHeight, dbProphecy.ScopedID()), []byte{})
+}
+
+// flushLiveness counts a finalized prophecy in the claim windows of the validators it was not counted for yet, if it
+// is still queued. It must be called before the snapshot or the votes of the prophecy are deleted, or before its
+// finalized height changes.
+func (k Keeper) flushLiveness(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.LivenessQueueKey(dbProphecy.FinalizedHeight, dbProphecy.ScopedID())
+	start := store.Get(key)
+	if start == nil {
+		return
+	}
+	k.handleLiveness(ctx, dbProphecy.ScopedID(), start, math.MaxUint64)
+	store.Delete(key)
+}
+
+// handleLiveness counts a finalized prophecy in the claim window of the validators of its snapshot, recording which
+// of them neither claimed on it nor rejected it. Validators that forfeited their claim already left the bonded
+// validator set and are not counted. Validators whose missed claims exceed what the minimum claimed per window allows
+// are jailed and their window is reset, as x/slashing does for missed blocks. It goes through at most limit
+// validators of the snapshot in address order from the start address, and returns how many it went through and the
+// address to start from next, nil once the whole snapshot was gone through.
+func (k Keeper) handleLiveness(
+	ctx sdk.Context, id string, start sdk.ValAddress, limit uint64,
+) (uint64, sdk.ValAddress) {
+	dbProphecy, found := k.getDBProphecy(ctx, id)
+	if !found {
+		return 0, nil
+	}
+
+	prefix := types.ValidatorPowersKey(id)
+	var validatorPowers []types.ValidatorPower
+	var next sdk.ValAddress
+	iter := ctx.KVStore(k.storeKey).Iterator(types.ValidatorPowerKey(id, start), sdk.PrefixEndBytes(prefix))
+	for ; iter.Valid(); iter.Next() {
+		if uint64(len(validatorPowers)) == limit {
+			next = sdk.ValAddress(iter.Key()[len(prefix):])
+			break
+		}
+		var validatorPower types.ValidatorPower
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &validatorPower)
+		validatorPowers = append(validatorPowers, validatorPower)
+	}
+	iter.Close()
+
+	params := k.GetParams(ctx)
+	maxMissed := params.ClaimWindow - params.MinClaimedPerWindow.MulInt64(params.ClaimWindow).RoundInt64()
+	for _, validatorPower := range validatorPowers {
+		if validatorPower.Forfeited {
+			continue
+		}
+
+		validator := validatorPower.Validator
+		liveness, found := k.GetValidatorLiveness(ctx, validator)
+		if !found {
+			liveness = types.NewValidatorLiveness(validator, 0, 0)
+		}
+
+		index := liveness.IndexOffset % params.ClaimWindow
+		liveness.IndexOffset++
+
+		previous := k.GetMissedClaim(ctx, validator, index)
+		// a rejection is a vote on the prophecy as much as a claim is
+		missed := !k.hasClaim(ctx, id, validator) && !k.hasRejection(ctx, id, validator)
+		switch {
+		case !previous && missed:
+			k.SetMissedClaim(ctx, validator, index, true)
+			liveness.MissedClaimsCounter++
+		case previous && !missed:
+			k.SetMissedClaim(ctx, validator, index, false)
+			liveness.MissedClaimsCounter--
+		}
+
+		if missed {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeLiveness,
+					sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+					sdk.NewAttribute(types.AttributeKeyNamespace, dbProphecy.Namespace),
+					sdk.NewAttribute(types.AttributeKeyProphecyID, dbProphecy.ID),
+					sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+					sdk.NewAttribute(types.AttributeKeyMissedClaims, fmt.Sprintf("%d", liveness.MissedClaimsCounter)),
+				),
+			)
+		}
+
+		// validators are only jailed once a full window of prophecies was counted for them
+		if liveness.IndexOffset >= params.ClaimWindow && liveness.MissedClaimsCounter > maxMissed &&
+			k.jailForLiveness(ctx, params, validator) {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeLivenessJail,
+					sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+					sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+					sdk.NewAttribute(types.AttributeKeyMissedClaims, fmt.Sprintf("%d", liveness.MissedClaimsCounter)),
+				),
+			)
+
+			// the validator starts over with a clean window once it is unjailed
+			liveness.IndexOffset = 0
+			liveness.MissedClaimsCounter = 0
+			k.clearMissedClaims(ctx, validator)
+		}
+
+		k.SetValidatorLiveness(ctx, liveness)
+	}
+	return uint64(len(validatorPowers)), next
+}
+
+// jailForLiveness jails a bonded validator for the liveness jail duration and returns whether it was jailed
+func (k Keeper) jailForLiveness(ctx sdk.Context, params types.Params, validatorAddress sdk.ValAddress) bool {
+	validator, found := k.stakeKeeper.GetValidator(ctx, validatorAddress)
+	if !found || !validator.IsBonded() || validator.IsJailed() {
+		return false
+	}
+
+	consAddr := validator.GetConsAddr()
+	k.slashingKeeper.Jail(ctx, consAddr)
+
+	// x/slashing only keeps signing info, which holds the jail time, for validators bonded while it was running
+	signingInfo, found := k.slashingKeeper.GetValidatorSigningInfo(ctx, consAddr)
+	jailedUntil := ctx.BlockHeader().Time.Add(params.LivenessJailDuration)
+	if found && signingInfo.JailedUntil.Before(jailedUntil) {
+		k.slashingKeeper.JailUntil(ctx, consAddr, jailedUntil)
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestLivenessMissedClaims(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+
+	params := keeper.GetParams(ctx)
+	params.ClaimWindow = 2
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	// the second validator does not claim on the first prophecy
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+	// the finalized prophecy is only counted once the liveness queue is processed
+	_, found := keeper.GetValidatorLiveness(ctx, validator1Pow3)
+	require.False(t, found)
+	require.Equal(t, uint64(3), keeper.ProcessLivenessQueue(ctx, params.MaxLivenessUpdatesPerBlock))
+
+	liveness, found := keeper.GetValidatorLiveness(ctx, validator2Pow3)
+	require.True(t, found)
+	require.Equal(t, types.NewValidatorLiveness(validator2Pow3, 1, 1), liveness)
+	require.True(t, keeper.GetMissedClaim(ctx, validator2Pow3, 0))
+	require.Equal(t, []types.MissedClaim{types.NewMissedClaim(validator2Pow3, 0)}, keeper.GetMissedClaims(ctx))
+
+	liveness, found = keeper.GetValidatorLiveness(ctx, validator1Pow3)
+	require.True(t, found)
+	require.Equal(t, types.NewValidatorLiveness(validator1Pow3, 1, 0), liveness)
+
+	// once it claims on a full window of prophecies the missed claim slides out of the window
+	for _, id := range []string{AlternateTestID, "thirdID"} {
+		for _, validator := range []sdk.ValAddress{validator2Pow3, validator3Pow4} {
+			_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, validator, TestString))
+			require.NoError(t, err)
+		}
+	}
+	keeper.ProcessLivenessQueue(ctx, params.MaxLivenessUpdatesPerBlock)
+
+	liveness, found = keeper.GetValidatorLiveness(ctx, validator2Pow3)
+	require.True(t, found)
+	require.Equal(t, types.NewValidatorLiveness(validator2Pow3, 3, 0), liveness)
+	require.False(t, keeper.GetMissedClaim(ctx, validator2Pow3, 0))
+
+	liveness, found = keeper.GetValidatorLiveness(ctx, validator1Pow3)
+	require.True(t, found)
+	require.Equal(t, types.NewValidatorLiveness(validator1Pow3, 3, 2), liveness)
+
+	// jailing is disabled by default
+	validator1, found := input.StakingKeeper.GetValidator(ctx, validator1Pow3)
+	require.True(t, found)
+	require.False(t, validator1.IsJailed())
+}
+
+func TestLivenessJail(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+
+	params := keeper.GetParams(ctx)
+	params.ClaimWindow = 2
+	params.MinClaimedPerWindow = sdk.NewDecWithPrec(5, 1)
+	params.LivenessJailDuration = time.Hour
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	// missing one claim of the window is allowed
+	for _, validator := range []sdk.ValAddress{validator1Pow3, validator3Pow4} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator, TestString))
+		require.NoError(t, err)
+	}
+	keeper.ProcessLivenessQueue(ctx, params.MaxLivenessUpdatesPerBlock)
+	validator2, found := input.StakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+	require.False(t, validator2.IsJailed())
+
+	// missing both gets the validator jailed and its window reset
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	for _, validator := range []sdk.ValAddress{validator1Pow3, validator3Pow4} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, AlternateTestID, validator, TestString))
+		require.NoError(t, err)
+	}
+	keeper.ProcessLivenessQueue(ctx, params.MaxLivenessUpdatesPerBlock)
+	validator2, found = input.StakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+	require.True(t, validator2.IsJailed())
+
+	liveness, found := keeper.GetValidatorLiveness(ctx, validator2Pow3)
+	require.True(t, found)
+	require.Equal(t, types.NewValidatorLiveness(validator2Pow3, 0, 0), liveness)
+	require.Empty(t, keeper.GetMissedClaims(ctx))
+
+	var jailEvents int
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeLivenessJail {
+			jailEvents++
+			require.Contains(t, event.Attributes,
+				sdk.NewAttribute(types.AttributeKeyValidator, validator2Pow3.String()).ToKVPair())
+		}
+	}
+	require.Equal(t, 1, jailEvents)
+}
+
+func TestLivenessQueue(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper := input.Ctx, input.OracleKeeper
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	for i, id := range []string{TestID, AlternateTestID} {
+		for _, validator := range []sdk.ValAddress{validator1Pow3, validator3Pow4} {
+			_, err := keeper.ProcessClaim(ctx.WithBlockHeight(int64(10+i)),
+				types.NewClaim(TestNamespace, id, validator, TestString))
+			require.NoError(t, err)
+		}
+	}
+
+	counted := func() int64 {
+		var indexOffsets int64
+		for _, liveness := range keeper.GetAllValidatorLiveness(ctx) {
+			indexOffsets += liveness.IndexOffset
+		}
+		return indexOffsets
+	}
+
+	// at most the given number of validators are counted per call, the next call carries on where it stopped
+	require.Equal(t, uint64(2), keeper.ProcessLivenessQueue(ctx, 2))
+	require.Equal(t, int64(2), counted())
+	require.Equal(t, uint64(2), keeper.ProcessLivenessQueue(ctx, 2))
+	require.Equal(t, int64(4), counted())
+	require.Equal(t, uint64(2), keeper.ProcessLivenessQueue(ctx, 10))
+	require.Equal(t, int64(6), counted())
+	require.Equal(t, uint64(0), keeper.ProcessLivenessQueue(ctx, 10))
+
+	// a prophecy reopened before it was counted is counted against the votes of the round that was finalized
+	params := keeper.GetParams(ctx)
+	params.ReopenCooldownBlocks = 1
+	keeper.SetParams(ctx, params)
+	_, err := keeper.ProcessClaim(ctx.WithBlockHeight(12),
+		types.NewClaim(TestNamespace, "thirdID", validator1Pow3, TestString))
+	require.NoError(t, err)
+	status, err := keeper.ProcessRejection(ctx.WithBlockHeight(12), TestNamespace, "thirdID", validator3Pow4)
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, status.Text)
+	_, err = keeper.ProcessClaim(ctx.WithBlockHeight(13),
+		types.NewClaim(TestNamespace, "thirdID", validator2Pow3, TestString))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), keeper.ProcessLivenessQueue(ctx, 10))
+
+	for _, expected := range []types.ValidatorLiveness{
+		types.NewValidatorLiveness(validator1Pow3, 3, 0),
+		types.NewValidatorLiveness(validator2Pow3, 3, 3),
+		types.NewValidatorLiveness(validator3Pow4, 3, 0),
+	} {
+		liveness, found := keeper.GetValidatorLiveness(ctx, expected.Validator)
+		require.True(t, found)
+		require.Equal(t, expected, liveness)
+	}
+}
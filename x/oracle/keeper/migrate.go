@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// MigrateStore converts the prophecies stored under their raw id with json serialized claims, before the store was
+// prefixed and prophecies were namespaced, into prophecies of the given namespace in the prefixed store layout. They
+// are stored under the id migrateID maps their raw id to, or under their raw id if it is not mapped. Legacy
+// prophecies recorded neither their creation height nor a validator set snapshot, they are migrated as created at
+// the current height and take a snapshot on their next claim. It must be called once, by the upgrade handler of the
+// chain, for the module that consumed prophecies before namespaces existed. It returns the number of migrated
+// prophecies.
+func (k Keeper) MigrateStore(ctx sdk.Context, namespace string, migrateID func(id string) (string, bool)) int {
+	store := ctx.KVStore(k.storeKey)
+
+	var legacyKeys [][]byte
+	var legacyProphecies []types.LegacyDBProphecy
+	iter := store.Iterator(types.LegacyProphecyKeyStart, nil)
+	for ; iter.Valid(); iter.Next() {
+		var legacyProphecy types.LegacyDBProphecy
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &legacyProphecy)
+		legacyKeys = append(legacyKeys, iter.Key())
+		legacyProphecies = append(legacyProphecies, legacyProphecy)
+	}
+	iter.Close()
+
+	for i, legacyProphecy := range legacyProphecies {
+		prophecy, err := legacyProphecy.ToProphecy(ctx.BlockHeight())
+		if err != nil {
+			panic(err)
+		}
+		store.Delete(legacyKeys[i])
+
+		prophecy.Namespace = namespace
+		if id, ok := migrateID(prophecy.ID); ok {
+			prophecy.ID = id
+		}
+		if err := types.ValidateProphecyID(namespace, prophecy.ID); err != nil {
+			panic(fmt.Sprintf("cannot migrate prophecy %s into namespace %s: %s", prophecy.ID, namespace, err))
+		}
+		if k.HasProphecy(ctx, namespace, prophecy.ID) {
+			panic(fmt.Sprintf("cannot migrate prophecy %s into namespace %s: it already exists", prophecy.ID,
+				namespace))
+		}
+		k.SetProphecy(ctx, prophecy)
+	}
+
+	return len(legacyProphecies)
+}
+
+// deleteProphecy removes a prophecy with its votes, past rounds, index entries and reference index entries
+func (k Keeper) deleteProphecy(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	store := ctx.KVStore(k.storeKey)
+	id := dbProphecy.ScopedID()
+	k.flushLiveness(ctx, dbProphecy)
+	contents := []string{dbProphecy.Status.FinalClaim}
+	for _, claim := range k.getClaims(ctx, id) {
+		contents = append(contents, claim.Content)
+	}
+	for _, pastRound := range k.getPastRounds(ctx, id) {
+		for _, claim := range pastRound.Claims {
+			contents = append(contents, claim.Content)
+		}
+	}
+	k.deleteVotes(ctx, id)
+	var keys [][]byte
+	for _, content := range contents {
+		for _, reference := range k.contentReferences(dbProphecy.Namespace, content) {
+			keys = append(keys, types.ReferenceKey(dbProphecy.Namespace, reference, id))
+		}
+	}
+	for _, prefix := range [][]byte{types.PastRoundsKey(id)} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		iter.Close()
+	}
+	keys = append(keys,
+		types.ProphecyKey(id),
+		types.ProphecyStatusIndexKey(dbProphecy.Status.Text, dbProphecy.CreationHeight, id),
+		types.ProphecyNamespaceStatusIndexKey(dbProphecy.Status.Text, id),
+		types.ProphecyCreationHeightIndexKey(dbProphecy.CreationHeight, id),
+	)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
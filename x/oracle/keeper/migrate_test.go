@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestMigrateStore(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	ctx = ctx.WithBlockHeight(10)
+	store := ctx.KVStore(keeper.storeKey)
+
+	validator1Pow3 := validatorAddresses[0]
+	validator2Pow7 := validatorAddresses[1]
+	migrateID := func(id string) (string, bool) {
+		return "new" + id, id != "unmapped"
+	}
+
+	setLegacyProphecy := func(id string, status types.Status, validatorClaims map[string]string) []byte {
+		claimValidators := make(map[string][]sdk.ValAddress)
+		for validatorBech32, claim := range validatorClaims {
+			validator, err := sdk.ValAddressFromBech32(validatorBech32)
+			require.NoError(t, err)
+			claimValidators[claim] = append(claimValidators[claim], validator)
+		}
+		claimValidatorsBytes, err := json.Marshal(claimValidators)
+		require.NoError(t, err)
+		validatorClaimsBytes, err := json.Marshal(validatorClaims)
+		require.NoError(t, err)
+
+		store.Set([]byte(id), keeper.cdc.MustMarshalBinaryBare(types.LegacyDBProphecy{
+			ID:              id,
+			Status:          status,
+			ClaimValidators: claimValidatorsBytes,
+			ValidatorClaims: validatorClaimsBytes,
+		}))
+		return []byte(id)
+	}
+	legacyKeys := [][]byte{
+		setLegacyProphecy(TestID, types.NewStatus(types.PendingStatusText, ""),
+			map[string]string{validator1Pow3.String(): AlternateTestString}),
+		setLegacyProphecy(AlternateTestID, types.NewStatus(types.SuccessStatusText, TestString),
+			map[string]string{validator1Pow3.String(): TestString, validator2Pow7.String(): TestString}),
+		setLegacyProphecy("unmapped", types.NewStatus(types.PendingStatusText, ""), map[string]string{}),
+	}
+
+	require.Equal(t, 3, keeper.MigrateStore(ctx, TestNamespace, migrateID))
+	for _, legacyKey := range legacyKeys {
+		require.False(t, store.Has(legacyKey))
+	}
+
+	// legacy prophecies are moved into the namespace under their mapped id, as created at the migration height
+	newID := "new" + TestID
+	prophecy, found := keeper.GetProphecy(ctx, TestNamespace, newID)
+	require.True(t, found)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	require.Equal(t, int64(10), prophecy.CreationHeight)
+	require.Zero(t, prophecy.FinalizedHeight)
+	require.Len(t, prophecy.Claims, 1)
+	claim, ok := prophecy.GetClaim(validator1Pow3)
+	require.True(t, ok)
+	require.Equal(t, AlternateTestString, claim)
+
+	prophecy, found = keeper.GetProphecy(ctx, TestNamespace, "new"+AlternateTestID)
+	require.True(t, found)
+	require.Equal(t, types.NewStatus(types.SuccessStatusText, TestString), prophecy.Status)
+	require.Equal(t, int64(10), prophecy.FinalizedHeight)
+	require.Len(t, prophecy.Claims, 2)
+
+	require.True(t, keeper.HasProphecy(ctx, TestNamespace, "unmapped"))
+
+	var pending []string
+	keeper.IteratePropheciesByStatus(ctx, types.PendingStatusText, func(prophecy types.Prophecy) bool {
+		pending = append(pending, prophecy.ID)
+		return false
+	})
+	require.ElementsMatch(t, []string{newID, "unmapped"}, pending)
+
+	// claims on the migrated prophecy are tallied with the claims made before the migration
+	status, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, newID, validator2Pow7, TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, status.Text)
+}
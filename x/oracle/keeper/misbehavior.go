@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// SetMisbehavior stores a misbehavior record
+func (k Keeper) SetMisbehavior(ctx sdk.Context, misbehavior types.Misbehavior) {
+	ctx.KVStore(k.storeKey).Set(
+		types.MisbehaviorKey(misbehavior.Validator, types.ScopedProphecyID(misbehavior.Namespace, misbehavior.ProphecyID)),
+		k.cdc.MustMarshalBinaryBare(misbehavior),
+	)
+}
+
+// GetMisbehaviors returns all misbehavior records in the store
+func (k Keeper) GetMisbehaviors(ctx sdk.Context) []types.Misbehavior {
+	var misbehaviors []types.Misbehavior
+	k.IterateMisbehaviors(ctx, func(misbehavior types.Misbehavior) bool {
+		misbehaviors = append(misbehaviors, misbehavior)
+		return false
+	})
+	return misbehaviors
+}
+
+// IterateMisbehaviors iterates over all misbehavior records in validator address and scoped prophecy id order and calls
+// the callback on each of them, stopping when the callback returns true
+func (k Keeper) IterateMisbehaviors(ctx sdk.Context, cb func(misbehavior types.Misbehavior) (stop bool)) {
+	k.iterateMisbehaviors(ctx, types.MisbehaviorKeyPrefix, cb)
+}
+
+// IterateMisbehaviorsByValidator iterates over the misbehavior records of a validator in scoped prophecy id order and
+// calls the callback on each of them, stopping when the callback returns true
+func (k Keeper) IterateMisbehaviorsByValidator(
+	ctx sdk.Context, validator sdk.ValAddress, cb func(misbehavior types.Misbehavior) (stop bool),
+) {
+	k.iterateMisbehaviors(ctx, types.MisbehaviorsByValidatorKey(validator), cb)
+}
+
+func (k Keeper) iterateMisbehaviors(
+	ctx sdk.Context, prefix []byte, cb func(misbehavior types.Misbehavior) (stop bool),
+) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var misbehavior types.Misbehavior
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &misbehavior)
+		if cb(misbehavior) {
+			break
+		}
+	}
+}
+
+// handleMisbehaviors records the validators whose claims contradict the final claim of a prophecy that just
+// succeeded, and acts on them according to the misbehavior policy
+func (k Keeper) handleMisbehaviors(ctx sdk.Context, dbProphecy types.DBProphecy) {
+	params := k.GetParams(ctx)
+	for _, claim := range k.getClaims(ctx, dbProphecy.ScopedID()) {
+		if claim.Content == dbProphecy.Status.FinalClaim {
+			continue
+		}
+
+		misbehavior := types.NewMisbehavior(dbProphecy.Namespace,
+			dbProphecy.ID, claim.Validator, claim.Content, dbProphecy.Status.FinalClaim, ctx.BlockHeight())
+		k.SetMisbehavior(ctx, misbehavior)
+		if params.MisbehaviorPolicy == types.MisbehaviorPolicySlash {
+			k.slashAndJail(ctx, params, dbProphecy.ScopedID(), claim)
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeMisbehavior,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(types.AttributeKeyNamespace, misbehavior.Namespace),
+				sdk.NewAttribute(types.AttributeKeyProphecyID, misbehavior.ProphecyID),
+				sdk.NewAttribute(types.AttributeKeyValidator, misbehavior.Validator.String()),
+				sdk.NewAttribute(types.AttributeKeyPolicy, params.MisbehaviorPolicy),
+			),
+		)
+	}
+}
+
+// slashAndJail slashes the validator that made the given claim on the prophecy with the given id by the misbehavior
+// slash fraction and jails it for the misbehavior jail duration. The claim is the infraction: the validator is
+// slashed on the power its claim was tallied with, and the delegations that were unbonding or redelegating at the
+// height of the claim are slashed with it. Validators that are already unbonded are left alone.
+func (k Keeper) slashAndJail(ctx sdk.Context, params types.Params, id string, claim types.ValidatorClaim) {
+	validator, found := k.stakeKeeper.GetValidator(ctx, claim.Validator)
+	if !found || validator.IsUnbonded() {
+		return
+	}
+
+	power := validator.GetConsensusPower()
+	if validatorPower, found := k.getValidatorPower(ctx, id, claim.Validator); found {
+		power = validatorPower.Power
+	}
+	consAddr := validator.GetConsAddr()
+	k.slashingKeeper.Slash(ctx, consAddr, params.MisbehaviorSlashFraction, power, claim.Height)
+	if !validator.IsJailed() {
+		k.slashingKeeper.Jail(ctx, consAddr)
+	}
+
+	// x/slashing only keeps signing info, which holds the jail time, for validators bonded while it was running
+	signingInfo, found := k.slashingKeeper.GetValidatorSigningInfo(ctx, consAddr)
+	jailedUntil := ctx.BlockHeader().Time.Add(params.MisbehaviorJailDuration)
+	if found && signingInfo.JailedUntil.Before(jailedUntil) {
+		k.slashingKeeper.JailUntil(ctx, consAddr, jailedUntil)
+	}
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestMisbehaviorEvidence(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper, stakingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	validator2, found := stakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, TestID, validator2Pow3, AlternateTestString),
+		types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+
+	misbehaviors := keeper.GetMisbehaviors(ctx)
+	require.Equal(t, []types.Misbehavior{
+		types.NewMisbehavior(TestNamespace, TestID, validator2Pow3, AlternateTestString, TestString, ctx.BlockHeight()),
+	}, misbehaviors)
+
+	var emitted bool
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != types.EventTypeMisbehavior {
+			continue
+		}
+		emitted = true
+		require.Contains(t, event.Attributes, sdk.NewAttribute(types.AttributeKeyValidator,
+			validator2Pow3.String()).ToKVPair())
+		require.Contains(t, event.Attributes, sdk.NewAttribute(types.AttributeKeyPolicy,
+			types.MisbehaviorPolicyEvidence).ToKVPair())
+	}
+	require.True(t, emitted)
+
+	// the evidence policy leaves the validator untouched
+	validator2After, found := stakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+	require.Equal(t, validator2.GetTokens(), validator2After.GetTokens())
+	require.False(t, validator2After.IsJailed())
+}
+
+func TestMisbehaviorSlash(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper, stakingKeeper, slashingKeeper := input.Ctx, input.OracleKeeper, input.StakingKeeper,
+		input.SlashingKeeper
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+
+	params := keeper.GetParams(ctx)
+	params.MisbehaviorPolicy = types.MisbehaviorPolicySlash
+	params.MisbehaviorSlashFraction = sdk.NewDecWithPrec(1, 1)
+	params.MisbehaviorJailDuration = time.Hour
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	validator2, found := stakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+	consAddr := validator2.GetConsAddr()
+	slashingKeeper.SetValidatorSigningInfo(ctx, consAddr,
+		slashing.NewValidatorSigningInfo(consAddr, 0, 0, time.Unix(0, 0), false, 0))
+
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, TestID, validator2Pow3, AlternateTestString),
+		types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+	require.Len(t, keeper.GetMisbehaviors(ctx), 1)
+
+	// the contradicting validator loses a tenth of its stake and is jailed for the jail duration
+	validator2After, found := stakingKeeper.GetValidator(ctx, validator2Pow3)
+	require.True(t, found)
+	expectedTokens := validator2.GetTokens().Sub(validator2.GetTokens().QuoRaw(10))
+	require.Equal(t, expectedTokens, validator2After.GetTokens())
+	require.True(t, validator2After.IsJailed())
+
+	signingInfo, found := slashingKeeper.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeader().Time.Add(time.Hour), signingInfo.JailedUntil)
+
+	// validators that agreed with the final claim are left alone
+	validator1, found := stakingKeeper.GetValidator(ctx, validator1Pow3)
+	require.True(t, found)
+	require.False(t, validator1.IsJailed())
+}
+
+func TestMisbehaviorSlashInfractionHeight(t *testing.T) {
+	input := CreateTestInput(t, 0.6, []int64{3, 3, 4}, "")
+	ctx, keeper, stakingKeeper := input.Ctx.WithBlockHeight(10), input.OracleKeeper, input.StakingKeeper
+
+	params := keeper.GetParams(ctx)
+	params.MisbehaviorPolicy = types.MisbehaviorPolicySlash
+	params.MisbehaviorSlashFraction = sdk.NewDecWithPrec(1, 1)
+	keeper.SetParams(ctx, params)
+
+	validator1Pow3 := input.ValidatorAddresses[0]
+	validator2Pow3 := input.ValidatorAddresses[1]
+	validator3Pow4 := input.ValidatorAddresses[2]
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator2Pow3, AlternateTestString))
+	require.NoError(t, err)
+
+	// stake that started unbonding after the contradicting claim was made is still slashed for it
+	ctx = ctx.WithBlockHeight(12)
+	delegator := sdk.AccAddress(validator2Pow3)
+	balance := sdk.TokensFromConsensusPower(1)
+	stakingKeeper.SetUnbondingDelegationEntry(ctx, delegator, validator2Pow3, 11, ctx.BlockTime().Add(time.Hour),
+		balance)
+
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validator1Pow3, TestString),
+		types.NewClaim(TestNamespace, TestID, validator3Pow4, TestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+	require.Len(t, keeper.GetMisbehaviors(ctx), 1)
+
+	unbondingDelegation, found := stakingKeeper.GetUnbondingDelegation(ctx, delegator, validator2Pow3)
+	require.True(t, found)
+	require.Equal(t, balance.Sub(balance.QuoRaw(10)), unbondingDelegation.Entries[0].Balance)
+}
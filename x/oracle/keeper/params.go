@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+// GetParams returns the total set of oracle parameters. Parameters that were never set, as on chains upgraded in place
+// from a version without them, have their default value.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	params = types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+// SetParams sets the total set of oracle parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// GetConsensusNeeded returns the minimum proportion of bonded stake needed for a prophecy to succeed
+func (k Keeper) GetConsensusNeeded(ctx sdk.Context) (consensusNeeded sdk.Dec) {
+	consensusNeeded = types.DefaultConsensusNeeded
+	k.paramSpace.GetIfExists(ctx, types.KeyConsensusNeeded, &consensusNeeded)
+	return consensusNeeded
+}
+
+// GetRejectionThreshold returns the minimum proportion of a prophecy's snapshot power that must reject it for it to
+// fail
+func (k Keeper) GetRejectionThreshold(ctx sdk.Context) (rejectionThreshold sdk.Dec) {
+	rejectionThreshold = types.DefaultRejectionThreshold
+	k.paramSpace.GetIfExists(ctx, types.KeyRejectionThreshold, &rejectionThreshold)
+	return rejectionThreshold
+}
@@ -0,0 +1,231 @@
+package keeper
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+const (
+	// defaultQueryLimit is the number of records returned per page when no limit is given
+	defaultQueryLimit = 100
+
+	// maxQueryLimit is the maximum number of records returned per page, larger limits are capped to it
+	maxQueryLimit = 1000
+
+	maxInt = int(^uint(0) >> 1)
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case types.QueryParams:
+			return queryParams(ctx, cdc, keeper)
+		case types.QueryProphecy:
+			return queryProphecy(ctx, cdc, req, keeper)
+		case types.QueryProphecies:
+			return queryProphecies(ctx, cdc, req, keeper)
+		case types.QueryMisbehaviors:
+			return queryMisbehaviors(ctx, cdc, req, keeper)
+		case types.QueryLiveness:
+			return queryLiveness(ctx, cdc, req, keeper)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown oracle query endpoint")
+		}
+	}
+}
+
+func queryParams(ctx sdk.Context, cdc *codec.Codec, keeper Keeper) ([]byte, error) {
+	params := keeper.GetParams(ctx)
+
+	res, err := codec.MarshalJSONIndent(cdc, params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryProphecy(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryProphecyParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	prophecy, found := keeper.GetProphecy(ctx, params.Namespace, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrProphecyNotFound, "%s in namespace %s", params.ID, params.Namespace)
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, prophecy)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryProphecies(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryPropheciesParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var status types.StatusText
+	if params.Status != "" {
+		var ok bool
+		if status, ok = types.StringToStatusText[params.Status]; !ok {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid prophecy status %s", params.Status)
+		}
+	}
+
+	if params.Namespace != "" {
+		if err := types.ValidateNamespace(params.Namespace); err != nil {
+			return nil, sdkerrors.Wrap(err, params.Namespace)
+		}
+	}
+
+	store := ctx.KVStore(keeper.storeKey)
+	var ids []string
+	var err error
+	switch {
+	case params.Status != "":
+		err = paginate(
+			sdk.KVStorePrefixIterator(store, types.ProphecyNamespaceStatusIndexByStatusKey(status, params.Namespace)),
+			params.Page, params.Limit,
+			func(key, _ []byte) {
+				ids = append(ids, types.SplitProphecyNamespaceStatusIndexKey(key))
+			},
+		)
+	default:
+		err = paginate(
+			sdk.KVStorePrefixIterator(store, types.ProphecyKey(types.ScopedProphecyID(params.Namespace, ""))),
+			params.Page, params.Limit,
+			func(key, _ []byte) {
+				ids = append(ids, types.SplitProphecyKey(key))
+			},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prophecies := []types.Prophecy{}
+	for _, id := range ids {
+		prophecy, found := keeper.getProphecy(ctx, id)
+		if !found {
+			return nil, sdkerrors.Wrapf(types.ErrProphecyNotFound, "%s is indexed but not stored", id)
+		}
+		prophecies = append(prophecies, prophecy)
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, prophecies)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryMisbehaviors(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryMisbehaviorsParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	prefix := types.MisbehaviorKeyPrefix
+	if !params.Validator.Empty() {
+		prefix = types.MisbehaviorsByValidatorKey(params.Validator)
+	}
+	misbehaviors := []types.Misbehavior{}
+	err := paginate(sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), prefix), params.Page, params.Limit,
+		func(_, value []byte) {
+			var misbehavior types.Misbehavior
+			keeper.cdc.MustUnmarshalBinaryBare(value, &misbehavior)
+			misbehaviors = append(misbehaviors, misbehavior)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, misbehaviors)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryLiveness(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryLivenessParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	store := ctx.KVStore(keeper.storeKey)
+	var iter sdk.Iterator
+	if params.Validator.Empty() {
+		iter = sdk.KVStorePrefixIterator(store, types.ValidatorLivenessKeyPrefix)
+	} else {
+		key := types.ValidatorLivenessKey(params.Validator)
+		iter = store.Iterator(key, sdk.InclusiveEndBytes(key))
+	}
+	validatorLiveness := []types.ValidatorLiveness{}
+	err := paginate(iter, params.Page, params.Limit,
+		func(_, value []byte) {
+			var liveness types.ValidatorLiveness
+			keeper.cdc.MustUnmarshalBinaryBare(value, &liveness)
+			validatorLiveness = append(validatorLiveness, liveness)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := codec.MarshalJSONIndent(cdc, validatorLiveness)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+// paginate calls the callback on the entries of the given page of limit entries of the iterator, or of
+// defaultQueryLimit entries when limit is zero, and closes the iterator. Limits above maxQueryLimit are capped to it.
+// The entries before the page are skipped without being decoded and iteration stops at the end of the page, so that
+// only the records of the page are loaded. It returns an error if the page is not positive or the limit is negative.
+func paginate(iter sdk.Iterator, page, limit int, cb func(key, value []byte)) error {
+	defer iter.Close()
+	if page < 1 {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "page %d must be positive", page)
+	}
+	if limit < 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "limit %d must not be negative", limit)
+	}
+	if limit == 0 {
+		limit = defaultQueryLimit
+	}
+	if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+
+	// pages starting past the largest int cannot have any entry
+	if page-1 > maxInt/limit {
+		return nil
+	}
+	skip := (page - 1) * limit
+	for ; iter.Valid() && limit > 0; iter.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		cb(iter.Key(), iter.Value())
+		limit--
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/sifchain/peggy/x/oracle/types"
+)
+
+func TestQueryParams(t *testing.T) {
+	ctx, keeper, _, _, _, _ := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	//Test wrong paths
+	bz, err := querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.Error(t, err)
+	require.Nil(t, bz)
+
+	//Test params query
+	bz, err = querier(ctx, []string{types.QueryParams}, abci.RequestQuery{})
+	require.NoError(t, err)
+
+	var params types.Params
+	require.NoError(t, cdc.UnmarshalJSON(bz, &params))
+	require.True(t, params.ConsensusNeeded.Equal(sdk.NewDecWithPrec(7, 1)))
+}
+
+func TestQueryProphecy(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validatorAddresses[0], TestString))
+	require.NoError(t, err)
+
+	bz, err := cdc.MarshalJSON(types.NewQueryProphecyParams(TestNamespace, TestID))
+	require.NoError(t, err)
+	res, err := querier(ctx, []string{types.QueryProphecy}, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+
+	var prophecy types.Prophecy
+	require.NoError(t, cdc.UnmarshalJSON(res, &prophecy))
+	expectedProphecy, found := keeper.GetProphecy(ctx, TestNamespace, TestID)
+	require.True(t, found)
+	require.Equal(t, expectedProphecy, prophecy)
+
+	//Test unknown prophecy
+	bz, err = cdc.MarshalJSON(types.NewQueryProphecyParams(TestNamespace, AlternateTestID))
+	require.NoError(t, err)
+	_, err = querier(ctx, []string{types.QueryProphecy}, abci.RequestQuery{Data: bz})
+	require.True(t, types.ErrProphecyNotFound.Is(err))
+
+	//Test bad request
+	_, err = querier(ctx, []string{types.QueryProphecy}, abci.RequestQuery{Data: bz[:len(bz)-1]})
+	require.Error(t, err)
+}
+
+func TestQueryProphecies(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{3, 7}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	// two pending prophecies and one successful one
+	for _, id := range []string{"a", "b"} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, id, validatorAddresses[0], TestString))
+		require.NoError(t, err)
+	}
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, "c", validatorAddresses[1], TestString))
+	require.NoError(t, err)
+
+	// and a pending prophecy of another namespace
+	keeper.RegisterNamespace("other", types.StringContentType{})
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim("other", "a", validatorAddresses[0], TestString))
+	require.NoError(t, err)
+
+	queryIDs := func(page, limit int, status, namespace string) []string {
+		bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(page, limit, status, namespace))
+		require.NoError(t, err)
+		res, err := querier(ctx, []string{types.QueryProphecies}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+
+		var prophecies []types.Prophecy
+		require.NoError(t, cdc.UnmarshalJSON(res, &prophecies))
+		ids := []string{}
+		for _, prophecy := range prophecies {
+			ids = append(ids, prophecy.ScopedID())
+		}
+		return ids
+	}
+
+	a, b, c := types.ScopedProphecyID(TestNamespace, "a"), types.ScopedProphecyID(TestNamespace, "b"),
+		types.ScopedProphecyID(TestNamespace, "c")
+	otherA := types.ScopedProphecyID("other", "a")
+	require.Equal(t, []string{a, b, c, otherA}, queryIDs(1, 0, "", ""))
+	require.Equal(t, []string{a, b, otherA}, queryIDs(1, 0, "pending", ""))
+	require.Equal(t, []string{c}, queryIDs(1, 0, "success", ""))
+	require.Equal(t, []string{}, queryIDs(1, 0, "failed", ""))
+	require.Equal(t, []string{a, b}, queryIDs(1, 2, "", ""))
+	require.Equal(t, []string{c, otherA}, queryIDs(2, 2, "", ""))
+	require.Equal(t, []string{}, queryIDs(3, 2, "", ""))
+	require.Equal(t, []string{a, b, c}, queryIDs(1, 0, "", TestNamespace))
+	require.Equal(t, []string{a, b}, queryIDs(1, 0, "pending", TestNamespace))
+	require.Equal(t, []string{otherA}, queryIDs(1, 0, "", "other"))
+	require.Equal(t, []string{b}, queryIDs(2, 1, "pending", TestNamespace))
+	require.Equal(t, []string{}, queryIDs(3, 1, "pending", TestNamespace))
+	require.Equal(t, []string{}, queryIDs(2, 0, "", ""))
+
+	// only the prophecies of the page are loaded
+	queryGas := func(status string) sdk.Gas {
+		gasCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(1, 1, status, ""))
+		require.NoError(t, err)
+		_, err = querier(gasCtx, []string{types.QueryProphecies}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+		return gasCtx.GasMeter().GasConsumed()
+	}
+	allGas, pendingGas := queryGas(""), queryGas("pending")
+	for i := 0; i < 10; i++ {
+		_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, fmt.Sprintf("d%d", i), validatorAddresses[0],
+			TestString))
+		require.NoError(t, err)
+	}
+	require.Equal(t, allGas, queryGas(""))
+	require.Equal(t, pendingGas, queryGas("pending"))
+
+	// the pending prophecies of the other namespaces are not scanned
+	namespaceGas := func() sdk.Gas {
+		gasCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(1, 0, "pending", "other"))
+		require.NoError(t, err)
+		_, err = querier(gasCtx, []string{types.QueryProphecies}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+		return gasCtx.GasMeter().GasConsumed()
+	}
+	otherGas := namespaceGas()
+	for i := 0; i < 10; i++ {
+		_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, fmt.Sprintf("e%d", i), validatorAddresses[0],
+			TestString))
+		require.NoError(t, err)
+	}
+	require.Equal(t, otherGas, namespaceGas())
+	require.Equal(t, []string{otherA}, queryIDs(1, 0, "pending", "other"))
+
+	//Test invalid status
+	bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(1, 0, "unknown", ""))
+	require.NoError(t, err)
+	_, err = querier(ctx, []string{types.QueryProphecies}, abci.RequestQuery{Data: bz})
+	require.Error(t, err)
+}
+
+func TestQueryMisbehaviors(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	for _, claim := range []types.Claim{
+		types.NewClaim(TestNamespace, TestID, validatorAddresses[0], TestString),
+		types.NewClaim(TestNamespace, TestID, validatorAddresses[1], AlternateTestString),
+		types.NewClaim(TestNamespace, TestID, validatorAddresses[2], TestString),
+		types.NewClaim(TestNamespace, AlternateTestID, validatorAddresses[0], AlternateTestString),
+		types.NewClaim(TestNamespace, AlternateTestID, validatorAddresses[1], TestString),
+		types.NewClaim(TestNamespace, AlternateTestID, validatorAddresses[2], TestString),
+	} {
+		_, err := keeper.ProcessClaim(ctx, claim)
+		require.NoError(t, err)
+	}
+
+	query := func(params types.QueryMisbehaviorsParams) []types.Misbehavior {
+		bz, err := cdc.MarshalJSON(params)
+		require.NoError(t, err)
+
+		bz, err = querier(ctx, []string{types.QueryMisbehaviors}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+
+		var misbehaviors []types.Misbehavior
+		require.NoError(t, cdc.UnmarshalJSON(bz, &misbehaviors))
+		return misbehaviors
+	}
+
+	require.Len(t, query(types.NewQueryMisbehaviorsParams(1, 0, nil)), 2)
+	require.Len(t, query(types.NewQueryMisbehaviorsParams(2, 1, nil)), 1)
+	require.Empty(t, query(types.NewQueryMisbehaviorsParams(3, 1, nil)))
+
+	misbehaviors := query(types.NewQueryMisbehaviorsParams(1, 0, validatorAddresses[1]))
+	require.Len(t, misbehaviors, 1)
+	require.Equal(t, TestID, misbehaviors[0].ProphecyID)
+	require.Equal(t, validatorAddresses[1], misbehaviors[0].Validator)
+
+	require.Empty(t, query(types.NewQueryMisbehaviorsParams(1, 0, validatorAddresses[2])))
+}
+
+func TestQueryLiveness(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.6, []int64{3, 3, 4}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	for _, validator := range []sdk.ValAddress{validatorAddresses[0], validatorAddresses[2]} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, TestID, validator, TestString))
+		require.NoError(t, err)
+	}
+	keeper.ProcessLivenessQueue(ctx, types.DefaultMaxLivenessUpdatesPerBlock)
+
+	query := func(params types.QueryLivenessParams) []types.ValidatorLiveness {
+		bz, err := cdc.MarshalJSON(params)
+		require.NoError(t, err)
+
+		bz, err = querier(ctx, []string{types.QueryLiveness}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+
+		var validatorLiveness []types.ValidatorLiveness
+		require.NoError(t, cdc.UnmarshalJSON(bz, &validatorLiveness))
+		return validatorLiveness
+	}
+
+	require.Len(t, query(types.NewQueryLivenessParams(1, 0, nil)), 3)
+	require.Len(t, query(types.NewQueryLivenessParams(2, 2, nil)), 1)
+
+	validatorLiveness := query(types.NewQueryLivenessParams(1, 0, validatorAddresses[1]))
+	require.Equal(t, []types.ValidatorLiveness{types.NewValidatorLiveness(validatorAddresses[1], 1, 1)},
+		validatorLiveness)
+
+	_, unknownValidators := CreateTestAddrs(4)
+	require.Empty(t, query(types.NewQueryLivenessParams(1, 0, unknownValidators[3])))
+}
+
+func TestQueryPagination(t *testing.T) {
+	ctx, keeper, _, _, _, validatorAddresses := CreateTestKeepers(t, 0.7, []int64{3, 7}, "")
+	cdc := MakeTestCodec()
+	querier := NewQuerier(keeper, cdc)
+
+	for i := 0; i < maxQueryLimit+1; i++ {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestNamespace, fmt.Sprintf("a%04d", i),
+			validatorAddresses[0], TestString))
+		require.NoError(t, err)
+	}
+
+	query := func(page, limit int) ([]types.Prophecy, error) {
+		bz, err := cdc.MarshalJSON(types.NewQueryPropheciesParams(page, limit, "", ""))
+		require.NoError(t, err)
+		res, err := querier(ctx, []string{types.QueryProphecies}, abci.RequestQuery{Data: bz})
+		if err != nil {
+			return nil, err
+		}
+		var prophecies []types.Prophecy
+		require.NoError(t, cdc.UnmarshalJSON(res, &prophecies))
+		return prophecies, nil
+	}
+
+	// limits are capped
+	prophecies, err := query(1, maxQueryLimit+1)
+	require.NoError(t, err)
+	require.Len(t, prophecies, maxQueryLimit)
+	prophecies, err = query(2, maxQueryLimit+1)
+	require.NoError(t, err)
+	require.Len(t, prophecies, 1)
+
+	// pages past the largest int are empty instead of wrapping around to the first page
+	for _, page := range []int{maxInt, maxInt/2 + 2} {
+		prophecies, err = query(page, 2)
+		require.NoError(t, err)
+		require.Empty(t, prophecies)
+	}
+
+	// pages must be positive and limits must not be negative
+	for _, params := range [][2]int{{0, 1}, {-1, 1}, {1, -1}, {1, -maxInt - 1}} {
+		_, err = query(params[0], params[1])
+		require.True(t, sdkerrors.ErrInvalidRequest.Is(err), "page %d limit %d", params[0], params[1])
+	}
+
+	validatorQueries := []struct {
+		path   string
+		params interface{}
+	}{
+		{types.QueryMisbehaviors, types.NewQueryMisbehaviorsParams(0, 1, nil)},
+		{types.QueryMisbehaviors, types.NewQueryMisbehaviorsParams(1, -1, nil)},
+		{types.QueryLiveness, types.NewQueryLivenessParams(0, 1, nil)},
+		{types.QueryLiveness, types.NewQueryLivenessParams(1, -1, nil)},
+	}
+	for _, q := range validatorQueries {
+		bz, err := cdc.MarshalJSON(q.params)
+		require.NoError(t, err)
+		_, err = querier(ctx, []string{q.path}, abci.RequestQuery{Data: bz})
+		require.True(t, sdkerrors.ErrInvalidRequest.Is(err), "%s %v", q.path, q.params)
+	}
+}
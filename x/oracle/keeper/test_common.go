@@ -20,6 +20,7 @@ import (
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -30,6 +31,7 @@ import (
 )
 
 const (
+	TestNamespace              = "oracletest"
 	TestID                     = "oracleID"
 	AlternateTestID            = "altOracleID"
 	TestString                 = "{value: 5}"
@@ -37,16 +39,43 @@ const (
 	AnotherAlternateTestString = "{value: 9}"
 )
 
+// TestInput holds the context and the keepers set up by CreateTestInput
+type TestInput struct {
+	Ctx                sdk.Context
+	OracleKeeper       Keeper
+	BankKeeper         bank.Keeper
+	SupplyKeeper       supply.Keeper
+	AccountKeeper      auth.AccountKeeper
+	ParamsKeeper       params.Keeper
+	StakingKeeper      staking.Keeper
+	SlashingKeeper     slashing.Keeper
+	ValidatorAddresses []sdk.ValAddress
+	// StoreKey is the key of the oracle store
+	StoreKey sdk.StoreKey
+	// ModuleStoreKey is the key of a store named after the extra module account, nil without one
+	ModuleStoreKey sdk.StoreKey
+}
+
 // CreateTestKeepers greates an Mock App, OracleKeeper, BankKeeper and ValidatorAddresses to be used for test input
 func CreateTestKeepers(t *testing.T, consensusNeeded float64, validatorAmounts []int64, extraMaccPerm string) (
 	sdk.Context, Keeper, bank.Keeper, supply.Keeper, auth.AccountKeeper, []sdk.ValAddress) {
-	PKs := CreateTestPubKeys(500)
+	input := CreateTestInput(t, consensusNeeded, validatorAmounts, extraMaccPerm)
+	return input.Ctx, input.OracleKeeper, input.BankKeeper, input.SupplyKeeper, input.AccountKeeper,
+		input.ValidatorAddresses
+}
+
+// CreateTestInput is CreateTestKeepers that also gives access to the ParamsKeeper, StakingKeeper and SlashingKeeper,
+// so that tests can change the validator set and modules depending on the oracle can create their own parameter
+// subspaces. Modules depending on the oracle also get a store named after their module account.
+func CreateTestInput(t testing.TB, consensusNeeded float64, validatorAmounts []int64, extraMaccPerm string) TestInput {
+	PKs := CreateTestPubKeys(len(validatorAmounts))
 	keyStaking := sdk.NewKVStoreKey(stakingtypes.StoreKey)
 	tkeyStaking := sdk.NewTransientStoreKey(stakingtypes.TStoreKey)
 	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
 	keyParams := sdk.NewKVStoreKey(params.StoreKey)
 	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
 	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keySlashing := sdk.NewKVStoreKey(slashing.StoreKey)
 	keyOracle := sdk.NewKVStoreKey(types.StoreKey)
 
 	db := dbm.NewMemDB()
@@ -57,7 +86,13 @@ func CreateTestKeepers(t *testing.T, consensusNeeded float64, validatorAmounts [
 	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySlashing, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyOracle, sdk.StoreTypeIAVL, db)
+	var keyModule sdk.StoreKey
+	if extraMaccPerm != "" {
+		keyModule = sdk.NewKVStoreKey(extraMaccPerm)
+		ms.MountStoreWithDB(keyModule, sdk.StoreTypeIAVL, db)
+	}
 	err := ms.LoadLatestVersion()
 	require.NoError(t, err)
 
@@ -114,8 +149,19 @@ func CreateTestKeepers(t *testing.T, consensusNeeded float64, validatorAmounts [
 	supplyKeeper.SetSupply(ctx, supply.NewSupply(totalSupply))
 
 	stakingKeeper := staking.NewKeeper(cdc, keyStaking, supplyKeeper, paramsKeeper.Subspace(staking.DefaultParamspace))
-	stakingKeeper.SetParams(ctx, stakingtypes.DefaultParams())
-	oracleKeeper := NewKeeper(cdc, keyOracle, stakingKeeper, consensusNeeded)
+	stakingParams := stakingtypes.DefaultParams()
+	if numValidators := uint16(len(validatorAmounts)); numValidators > stakingParams.MaxValidators {
+		stakingParams.MaxValidators = numValidators
+	}
+	stakingKeeper.SetParams(ctx, stakingParams)
+	slashingKeeper := slashing.NewKeeper(cdc, keySlashing, &stakingKeeper, paramsKeeper.Subspace(slashing.DefaultParamspace))
+	slashingKeeper.SetParams(ctx, slashing.DefaultParams())
+	oracleKeeper := NewKeeper(
+		cdc, keyOracle, paramsKeeper.Subspace(types.DefaultParamspace), stakingKeeper, slashingKeeper)
+	oracleKeeper.RegisterNamespace(TestNamespace, types.StringContentType{})
+	params := types.DefaultParams()
+	params.ConsensusNeeded = sdk.MustNewDecFromStr(strconv.FormatFloat(consensusNeeded, 'f', -1, 64))
+	oracleKeeper.SetParams(ctx, params)
 
 	// set module accounts
 	err = notBondedPool.SetCoins(totalSupply)
@@ -136,11 +182,24 @@ func CreateTestKeepers(t *testing.T, consensusNeeded float64, validatorAmounts [
 		validator := stakingtypes.NewValidator(valAddr, valPubKey, stakingtypes.Description{})
 		validator, _ = validator.AddTokensFromDel(valTokens)
 		stakingKeeper.SetValidator(ctx, validator)
+		stakingKeeper.SetValidatorByConsAddr(ctx, validator)
 		stakingKeeper.SetValidatorByPowerIndex(ctx, validator)
-		stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
 	}
-
-	return ctx, oracleKeeper, bankKeeper, supplyKeeper, accountKeeper, valAddrs
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	return TestInput{
+		Ctx:                ctx,
+		OracleKeeper:       oracleKeeper,
+		BankKeeper:         bankKeeper,
+		SupplyKeeper:       supplyKeeper,
+		AccountKeeper:      accountKeeper,
+		ParamsKeeper:       paramsKeeper,
+		StakingKeeper:      stakingKeeper,
+		SlashingKeeper:     slashingKeeper,
+		ValidatorAddresses: valAddrs,
+		StoreKey:           keyOracle,
+		ModuleStoreKey:     keyModule,
+	}
 }
 
 // nolint: unparam
@@ -174,10 +233,13 @@ func CreateTestPubKeys(numPubKeys int) []crypto.PubKey {
 	//start at 10 to avoid changing 1 to 01, 2 to 02, etc
 	for i := 100; i < (numPubKeys + 100); i++ {
 		numString := strconv.Itoa(i)
-		buffer.WriteString(
-			"0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AF",
-		) //base pubkey string
-		buffer.WriteString(numString) //adding on final two digits to make pubkeys unique
+		basePubKey := "0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AF"
+		if len(numString) > 3 {
+			// four digit numbers replace the last character of the base pubkey, which is not a digit
+			basePubKey = basePubKey[:len(basePubKey)-1]
+		}
+		buffer.WriteString(basePubKey) //base pubkey string
+		buffer.WriteString(numString)  //adding on final two digits to make pubkeys unique
 		publicKeys = append(publicKeys, NewPubKey(buffer.String()))
 		buffer.Reset()
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/gorilla/mux"
+	"github.com/sifchain/peggy/x/oracle/client"
 	"github.com/spf13/cobra"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -30,21 +31,28 @@ func (AppModuleBasic) Name() string {
 }
 
 // RegisterCodec registers the oracle module's types for the given codec.
-func (AppModuleBasic) RegisterCodec(_ *codec.Codec) {}
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
+	RegisterCodec(cdc)
+}
 
 // DefaultGenesis returns default genesis state as raw bytes for the oracle
 // module.
 func (AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return nil
+	return ModuleCdc.MustMarshalJSON(DefaultGenesisState())
 }
 
 // ValidateGenesis performs genesis state validation for the oracle module.
-func (AppModuleBasic) ValidateGenesis(_ json.RawMessage) error {
-	return nil
+func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data GenesisState
+	if err := ModuleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+	return ValidateGenesis(data)
 }
 
 // RegisterRESTRoutes registers the REST routes for the oracle module.
 func (AppModuleBasic) RegisterRESTRoutes(ctx context.CLIContext, rtr *mux.Router) {
+	client.RegisterRESTRoutes(ctx, rtr, QuerierRoute)
 }
 
 // GetTxCmd returns the root tx command for the oracle module.
@@ -52,9 +60,9 @@ func (AppModuleBasic) GetTxCmd(_ *codec.Codec) *cobra.Command {
 	return nil
 }
 
-// GetQueryCmd returns no root query command for the oracle module.
-func (AppModuleBasic) GetQueryCmd(_ *codec.Codec) *cobra.Command {
-	return nil
+// GetQueryCmd returns the root query command for the oracle module.
+func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	return client.GetQueryCmd(QuerierRoute, cdc)
 }
 
 //____________________________________________________________________________
@@ -86,6 +94,7 @@ func (AppModule) Name() string {
 
 // RegisterInvariants registers the oracle module invariants.
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, am.keeper)
 }
 
 // Route returns the message routing key for the oracle module.
@@ -100,24 +109,28 @@ func (am AppModule) NewHandler() sdk.Handler {
 
 // QuerierRoute returns the oracle module's querier route name.
 func (AppModule) QuerierRoute() string {
-	return ""
+	return QuerierRoute
 }
 
 // NewQuerierHandler returns the oracle module sdk.Querier.
 func (am AppModule) NewQuerierHandler() sdk.Querier {
-	return nil
+	return NewQuerier(am.keeper, ModuleCdc)
 }
 
 // InitGenesis performs genesis initialization for the oracle module. It returns
 // no validator updates.
-func (am AppModule) InitGenesis(_ sdk.Context, _ json.RawMessage) []abci.ValidatorUpdate {
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	ModuleCdc.MustUnmarshalJSON(data, &genesisState)
+	InitGenesis(ctx, am.keeper, genesisState)
 	return nil
 }
 
 // ExportGenesis returns the exported genesis state as raw bytes for the oracle
 // module.
 func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
-	return nil
+	gs := ExportGenesis(ctx, am.keeper)
+	return ModuleCdc.MustMarshalJSON(gs)
 }
 
 // BeginBlock returns the begin blocker for the oracle module.
@@ -126,5 +139,6 @@ func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 // EndBlock returns the end blocker for the oracle module. It returns no validator
 // updates.
 func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlocker(ctx, am.keeper)
 	return nil
 }
@@ -2,16 +2,19 @@ package types
 
 import sdk "github.com/cosmos/cosmos-sdk/types"
 
-// Claim contrains an arbitrary claim with arbitrary content made by a given validator
+// Claim contrains an arbitrary claim with arbitrary content made by a given validator. Its content must be of the
+// claim content type registered for its namespace.
 type Claim struct {
+	Namespace        string         `json:"namespace"`
 	ID               string         `json:"id"`
 	ValidatorAddress sdk.ValAddress `json:"validator_address"`
 	Content          string         `json:"content"`
 }
 
 // NewClaim returns a new Claim
-func NewClaim(id string, validatorAddress sdk.ValAddress, content string) Claim {
+func NewClaim(namespace, id string, validatorAddress sdk.ValAddress, content string) Claim {
 	return Claim{
+		Namespace:        namespace,
 		ID:               id,
 		ValidatorAddress: validatorAddress,
 		Content:          content,
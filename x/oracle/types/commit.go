@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CommitmentLength is the length of a claim commitment
+const CommitmentLength = tmhash.Size
+
+// ClaimCommit contains the commitment of a validator to a claim it will reveal on a prophecy once its commit window
+// closes. Only the commitment is published during the commit window, so validators cannot copy the claims of others.
+type ClaimCommit struct {
+	Namespace        string         `json:"namespace"`
+	ID               string         `json:"id"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address"`
+	Commitment       []byte         `json:"commitment"`
+}
+
+// NewClaimCommit returns a new ClaimCommit
+func NewClaimCommit(namespace, id string, validatorAddress sdk.ValAddress, commitment []byte) ClaimCommit {
+	return ClaimCommit{
+		Namespace:        namespace,
+		ID:               id,
+		ValidatorAddress: validatorAddress,
+		Commitment:       commitment,
+	}
+}
+
+// ClaimCommitment returns the commitment of a validator to the content of a claim. The salt keeps claims with few
+// possible contents from being guessed from their commitment, and the validator address keeps validators from
+// committing to the commitment of another.
+func ClaimCommitment(salt string, validator sdk.ValAddress, content string) []byte {
+	return tmhash.Sum([]byte(fmt.Sprintf("%s:%s:%s", salt, validator, content)))
+}
+
+// ValidatorCommit is the commitment a single validator made on a prophecy
+type ValidatorCommit struct {
+	Validator  sdk.ValAddress `json:"validator"`
+	Commitment []byte         `json:"commitment"`
+}
+
+// NewValidatorCommit returns a new ValidatorCommit
+func NewValidatorCommit(validator sdk.ValAddress, commitment []byte) ValidatorCommit {
+	return ValidatorCommit{
+		Validator:  validator,
+		Commitment: commitment,
+	}
+}
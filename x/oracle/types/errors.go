@@ -11,11 +11,22 @@ var (
 		"minimum consensus proportion of validator staking power must be > 0 and <= 1")
 	ErrNoClaims          = sdkerrors.Register(ModuleName, 3, "cannot create prophecy without initial claim")
 	ErrInvalidIdentifier = sdkerrors.Register(ModuleName, 4,
-		"invalid identifier provided, must be a nonempty string")
+		"invalid identifier provided, must be a nonempty string starting with a printable character")
 	ErrProphecyFinalized = sdkerrors.Register(ModuleName, 5, "prophecy already finalized")
 	ErrDuplicateMessage  = sdkerrors.Register(ModuleName, 6,
 		"already processed message from validator for this id")
 	ErrInvalidClaim     = sdkerrors.Register(ModuleName, 7, "claim cannot be empty string")
 	ErrInvalidValidator = sdkerrors.Register(ModuleName, 8, "claim must be made by actively bonded validator")
 	ErrInternalDB       = sdkerrors.Register(ModuleName, 9, " failed prophecy serialization/deserialization")
+	ErrInvalidNamespace = sdkerrors.Register(ModuleName, 10,
+		"invalid namespace, must be lowercase letters, digits and underscores starting with a letter")
+	ErrUnknownNamespace = sdkerrors.Register(ModuleName, 11, "namespace not registered")
+	ErrCommitRequired   = sdkerrors.Register(ModuleName, 12,
+		"claims on this prophecy must be committed before they are revealed")
+	ErrCommitWindowClosed = sdkerrors.Register(ModuleName, 13, "the commit window of this prophecy is closed")
+	ErrRevealTooEarly     = sdkerrors.Register(ModuleName, 14,
+		"claims cannot be revealed before the commit window of the prophecy closes")
+	ErrInvalidReveal     = sdkerrors.Register(ModuleName, 15, "revealed claim does not match its commitment")
+	ErrInvalidResolution = sdkerrors.Register(ModuleName, 16,
+		"prophecies can only be resolved as failed or successful")
 )
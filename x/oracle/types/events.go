@@ -0,0 +1,23 @@
+package types
+
+// Oracle module event types
+const (
+	EventTypeProphecyExpired   = "prophecy_expired"
+	EventTypeProphecyRetallied = "prophecy_retallied"
+	EventTypeProphecyReopened  = "prophecy_reopened"
+	EventTypeProphecyResolved  = "prophecy_resolved"
+	EventTypeMisbehavior       = "oracle_misbehavior"
+	EventTypeLiveness          = "oracle_liveness"
+	EventTypeLivenessJail      = "oracle_liveness_jail"
+
+	AttributeKeyNamespace      = "namespace"
+	AttributeKeyProphecyID     = "prophecy_id"
+	AttributeKeyCreationHeight = "creation_height"
+	AttributeKeyValidator      = "validator"
+	AttributeKeyPolicy         = "policy"
+	AttributeKeyMissedClaims   = "missed_claims"
+	AttributeKeyRound          = "round"
+	AttributeKeyStatus         = "status"
+
+	AttributeValueCategory = ModuleName
+)
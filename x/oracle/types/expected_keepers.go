@@ -1,7 +1,10 @@
 package types // noalias
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 )
 
@@ -10,5 +13,13 @@ type StakingKeeper interface {
 	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator staking.Validator, found bool)
 	GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) (power int64)
 	GetLastTotalPower(ctx sdk.Context) (power sdk.Int)
-	GetBondedValidatorsByPower(ctx sdk.Context) []staking.Validator
+	IterateLastValidatorPowers(ctx sdk.Context, handler func(operator sdk.ValAddress, power int64) (stop bool))
+}
+
+// SlashingKeeper defines the expected slashing keeper
+type SlashingKeeper interface {
+	Slash(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, power, distributionHeight int64)
+	Jail(ctx sdk.Context, consAddr sdk.ConsAddress)
+	JailUntil(ctx sdk.Context, consAddr sdk.ConsAddress, jailTime time.Time)
+	GetValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress) (info slashing.ValidatorSigningInfo, found bool)
 }
@@ -0,0 +1,10 @@
+package types
+
+const (
+	// FlagStatus flag for filtering prophecies by their status
+	FlagStatus string = "status"
+	// FlagValidator flag for filtering misbehaviors and liveness records by validator
+	FlagValidator string = "validator"
+	// FlagNamespace flag for filtering prophecies by namespace
+	FlagNamespace string = "namespace"
+)
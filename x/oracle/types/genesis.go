@@ -0,0 +1,323 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GenesisState defines the oracle module's genesis state
+type GenesisState struct {
+	Params       Params        `json:"params" yaml:"params"`
+	Prophecies   []Prophecy    `json:"prophecies" yaml:"prophecies"`
+	Misbehaviors []Misbehavior `json:"misbehaviors" yaml:"misbehaviors"`
+	// ValidatorLiveness and MissedClaims hold the claim window of each validator
+	ValidatorLiveness []ValidatorLiveness `json:"validator_liveness" yaml:"validator_liveness"`
+	MissedClaims      []MissedClaim       `json:"missed_claims" yaml:"missed_claims"`
+}
+
+// NewGenesisState creates a new GenesisState instance
+func NewGenesisState(
+	params Params, prophecies []Prophecy, misbehaviors []Misbehavior, validatorLiveness []ValidatorLiveness,
+	missedClaims []MissedClaim,
+) GenesisState {
+	return GenesisState{
+		Params:            params,
+		Prophecies:        prophecies,
+		Misbehaviors:      misbehaviors,
+		ValidatorLiveness: validatorLiveness,
+		MissedClaims:      missedClaims,
+	}
+}
+
+// DefaultGenesisState returns the default oracle genesis state
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(DefaultParams(), []Prophecy{}, []Misbehavior{}, []ValidatorLiveness{}, []MissedClaim{})
+}
+
+// RebaseHeights returns the genesis state exported at the given height for a chain that restarts from height zero.
+// The heights of the prophecies, their past rounds, claims and misbehaviors are made relative to the export height,
+// heights that are not after it become zero. Commit windows that closed close at height one instead, so that their
+// prophecies keep their commits.
+func (data GenesisState) RebaseHeights(height int64) GenesisState {
+	rebase := func(h int64) int64 {
+		if h <= height {
+			return 0
+		}
+		return h - height
+	}
+	rebaseClaims := func(claims []ValidatorClaim) []ValidatorClaim {
+		claims = append([]ValidatorClaim(nil), claims...)
+		for i := range claims {
+			claims[i].Height = rebase(claims[i].Height)
+		}
+		return claims
+	}
+
+	prophecies := append([]Prophecy(nil), data.Prophecies...)
+	for i := range prophecies {
+		prophecy := &prophecies[i]
+		prophecy.CreationHeight = rebase(prophecy.CreationHeight)
+		if prophecy.CommitEndHeight != 0 {
+			prophecy.CommitEndHeight = rebase(prophecy.CommitEndHeight)
+			if prophecy.CommitEndHeight == 0 {
+				prophecy.CommitEndHeight = 1
+			}
+		}
+		prophecy.FinalizedHeight = rebase(prophecy.FinalizedHeight)
+		prophecy.Claims = rebaseClaims(prophecy.Claims)
+
+		prophecy.PastRounds = append([]ProphecyRound(nil), prophecy.PastRounds...)
+		for j := range prophecy.PastRounds {
+			pastRound := &prophecy.PastRounds[j]
+			pastRound.CreationHeight = rebase(pastRound.CreationHeight)
+			pastRound.FinalizedHeight = rebase(pastRound.FinalizedHeight)
+			pastRound.Claims = rebaseClaims(pastRound.Claims)
+		}
+	}
+
+	misbehaviors := append([]Misbehavior(nil), data.Misbehaviors...)
+	for i := range misbehaviors {
+		misbehaviors[i].Height = rebase(misbehaviors[i].Height)
+	}
+
+	return NewGenesisState(data.Params, prophecies, misbehaviors, data.ValidatorLiveness, data.MissedClaims)
+}
+
+// ValidateGenesis performs basic validation of the oracle genesis state
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	seenIDs := make(map[string]bool)
+	for _, prophecy := range data.Prophecies {
+		if seenIDs[prophecy.ScopedID()] {
+			return fmt.Errorf("duplicate prophecy id %s in namespace %s", prophecy.ID, prophecy.Namespace)
+		}
+		seenIDs[prophecy.ScopedID()] = true
+
+		if err := validateProphecy(prophecy); err != nil {
+			return err
+		}
+	}
+
+	seenMisbehaviors := make(map[string]bool)
+	for _, misbehavior := range data.Misbehaviors {
+		if err := misbehavior.Validate(); err != nil {
+			return err
+		}
+
+		key := string(MisbehaviorKey(misbehavior.Validator,
+			ScopedProphecyID(misbehavior.Namespace, misbehavior.ProphecyID)))
+		if seenMisbehaviors[key] {
+			return fmt.Errorf("duplicate misbehavior of %s on prophecy %s", misbehavior.Validator,
+				misbehavior.ProphecyID)
+		}
+		seenMisbehaviors[key] = true
+	}
+
+	return validateClaimWindows(data.Params, data.ValidatorLiveness, data.MissedClaims)
+}
+
+// validateClaimWindows checks that every missed claim is within the claim window of a validator with a liveness
+// record, and that each liveness record counts exactly the missed claims of its validator
+func validateClaimWindows(params Params, validatorLiveness []ValidatorLiveness, missedClaims []MissedClaim) error {
+	missedClaimsCounters := make(map[string]int64)
+	for _, liveness := range validatorLiveness {
+		if err := liveness.Validate(); err != nil {
+			return err
+		}
+		if _, seen := missedClaimsCounters[string(liveness.Validator)]; seen {
+			return fmt.Errorf("duplicate liveness record of %s", liveness.Validator)
+		}
+		missedClaimsCounters[string(liveness.Validator)] = liveness.MissedClaimsCounter
+	}
+
+	seenMissedClaims := make(map[string]bool)
+	for _, missedClaim := range missedClaims {
+		if _, found := missedClaimsCounters[string(missedClaim.Validator)]; !found {
+			return fmt.Errorf("missed claim of %s without a liveness record", missedClaim.Validator)
+		}
+		if missedClaim.Index < 0 || missedClaim.Index >= params.ClaimWindow {
+			return fmt.Errorf("missed claim of %s at index %d is outside the claim window", missedClaim.Validator,
+				missedClaim.Index)
+		}
+
+		key := string(MissedClaimKey(missedClaim.Validator, missedClaim.Index))
+		if seenMissedClaims[key] {
+			return fmt.Errorf("duplicate missed claim of %s at index %d", missedClaim.Validator, missedClaim.Index)
+		}
+		seenMissedClaims[key] = true
+		missedClaimsCounters[string(missedClaim.Validator)]--
+	}
+
+	for _, liveness := range validatorLiveness {
+		if missedClaimsCounters[string(liveness.Validator)] != 0 {
+			return fmt.Errorf("missed claims counter of %s does not match its missed claims", liveness.Validator)
+		}
+	}
+
+	return nil
+}
+
+// validateProphecy checks that a prophecy's claims, rejections, validator powers and status are consistent with each
+// other
+func validateProphecy(prophecy Prophecy) error {
+	// records without a namespace predate namespaces, they are moved into the namespace of their consumer
+	if prophecy.Namespace != "" {
+		if err := ValidateNamespace(prophecy.Namespace); err != nil {
+			return err
+		}
+	}
+	if err := ValidateProphecyID(prophecy.Namespace, prophecy.ID); err != nil {
+		return err
+	}
+	if prophecy.CreationHeight < 0 {
+		return fmt.Errorf("prophecy %s has a negative creation height", prophecy.ID)
+	}
+
+	for i, validatorClaim := range prophecy.Claims {
+		if validatorClaim.Validator.Empty() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "prophecy %s: empty validator in claims", prophecy.ID)
+		}
+		if validatorClaim.Content == "" {
+			return sdkerrors.Wrapf(ErrInvalidClaim, "prophecy %s: empty claim of validator %s", prophecy.ID,
+				validatorClaim.Validator)
+		}
+		if i > 0 && bytes.Compare(prophecy.Claims[i-1].Validator, validatorClaim.Validator) >= 0 {
+			return sdkerrors.Wrapf(ErrInvalidClaim, "prophecy %s: claims are not sorted by unique validator address",
+				prophecy.ID)
+		}
+	}
+
+	if prophecy.CommitEndHeight != 0 && prophecy.CommitEndHeight <= prophecy.CreationHeight {
+		return fmt.Errorf("prophecy %s has a commit window closing before its creation", prophecy.ID)
+	}
+	if prophecy.CommitEndHeight == 0 && len(prophecy.Commits) > 0 {
+		return fmt.Errorf("prophecy %s has commits but no commit window", prophecy.ID)
+	}
+	for i, validatorCommit := range prophecy.Commits {
+		if validatorCommit.Validator.Empty() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "prophecy %s: empty validator in commits", prophecy.ID)
+		}
+		if len(validatorCommit.Commitment) != CommitmentLength {
+			return fmt.Errorf("prophecy %s: commitment of validator %s is not %d bytes long", prophecy.ID,
+				validatorCommit.Validator, CommitmentLength)
+		}
+		if i > 0 && bytes.Compare(prophecy.Commits[i-1].Validator, validatorCommit.Validator) >= 0 {
+			return fmt.Errorf("prophecy %s: commits are not sorted by unique validator address", prophecy.ID)
+		}
+	}
+
+	for i, validator := range prophecy.Rejections {
+		if validator.Empty() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "prophecy %s: empty validator in rejections", prophecy.ID)
+		}
+		if i > 0 && bytes.Compare(prophecy.Rejections[i-1], validator) >= 0 {
+			return fmt.Errorf("prophecy %s: rejections are not sorted by unique validator address", prophecy.ID)
+		}
+		if _, claimed := prophecy.GetClaim(validator); claimed {
+			return sdkerrors.Wrapf(ErrDuplicateMessage, "prophecy %s: validator %s both claimed and rejected",
+				prophecy.ID, validator)
+		}
+	}
+	if len(prophecy.Rejections) > 0 && len(prophecy.ValidatorPowers) == 0 {
+		return fmt.Errorf("prophecy %s has rejections but no validator powers", prophecy.ID)
+	}
+
+	// Prophecies created before validator sets were snapshotted have an empty snapshot
+	for i, validatorPower := range prophecy.ValidatorPowers {
+		if validatorPower.Validator.Empty() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "prophecy %s: empty validator in validator powers",
+				prophecy.ID)
+		}
+		if validatorPower.Power <= 0 {
+			return fmt.Errorf("prophecy %s: validator %s has non-positive power %d", prophecy.ID,
+				validatorPower.Validator, validatorPower.Power)
+		}
+		if i > 0 && bytes.Compare(prophecy.ValidatorPowers[i-1].Validator, validatorPower.Validator) >= 0 {
+			return fmt.Errorf("prophecy %s: validator powers are not sorted by unique validator address",
+				prophecy.ID)
+		}
+	}
+	if len(prophecy.ValidatorPowers) > 0 {
+		for _, validatorPower := range prophecy.ValidatorPowers {
+			if _, claimed := prophecy.GetClaim(validatorPower.Validator); claimed && validatorPower.Forfeited {
+				return fmt.Errorf("prophecy %s: validator %s forfeited the claim it made", prophecy.ID,
+					validatorPower.Validator)
+			}
+			if prophecy.HasRejected(validatorPower.Validator) && validatorPower.Forfeited {
+				return fmt.Errorf("prophecy %s: validator %s forfeited the rejection it made", prophecy.ID,
+					validatorPower.Validator)
+			}
+		}
+		for _, validator := range prophecy.Rejections {
+			if _, ok := prophecy.GetValidatorPower(validator); !ok {
+				return sdkerrors.Wrapf(ErrInvalidValidator, "prophecy %s: validator %s is not in its validator powers",
+					prophecy.ID, validator)
+			}
+		}
+		for _, validatorClaim := range prophecy.Claims {
+			if _, ok := prophecy.GetValidatorPower(validatorClaim.Validator); !ok {
+				return sdkerrors.Wrapf(ErrInvalidValidator, "prophecy %s: validator %s is not in its validator powers",
+					prophecy.ID, validatorClaim.Validator)
+			}
+		}
+		for _, validatorCommit := range prophecy.Commits {
+			if _, ok := prophecy.GetValidatorPower(validatorCommit.Validator); !ok {
+				return sdkerrors.Wrapf(ErrInvalidValidator, "prophecy %s: validator %s is not in its validator powers",
+					prophecy.ID, validatorCommit.Validator)
+			}
+		}
+	}
+	if prophecy.CommitEndHeight != 0 {
+		for _, validatorClaim := range prophecy.Claims {
+			if _, ok := prophecy.GetCommit(validatorClaim.Validator); !ok {
+				return sdkerrors.Wrapf(ErrCommitRequired, "prophecy %s: validator %s revealed a claim it did not commit",
+					prophecy.ID, validatorClaim.Validator)
+			}
+		}
+	}
+
+	if prophecy.Status.Text == PendingStatusText && prophecy.FinalizedHeight != 0 {
+		return fmt.Errorf("pending prophecy %s cannot have a finalized height", prophecy.ID)
+	}
+	if prophecy.FinalizedHeight != 0 && prophecy.FinalizedHeight < prophecy.CreationHeight {
+		return fmt.Errorf("prophecy %s was finalized before its creation", prophecy.ID)
+	}
+	if uint64(len(prophecy.PastRounds)) != prophecy.Round {
+		return fmt.Errorf("prophecy %s is in round %d but has %d past rounds", prophecy.ID, prophecy.Round,
+			len(prophecy.PastRounds))
+	}
+	for i, pastRound := range prophecy.PastRounds {
+		if pastRound.Round != uint64(i) {
+			return fmt.Errorf("prophecy %s: past rounds are not sorted by consecutive round", prophecy.ID)
+		}
+		if !CanReopen(pastRound.Status.Text) {
+			return fmt.Errorf("prophecy %s: %s round %d cannot have been reopened", prophecy.ID,
+				pastRound.Status.Text, pastRound.Round)
+		}
+		if pastRound.CreationHeight > prophecy.CreationHeight {
+			return fmt.Errorf("prophecy %s: round %d was created after the current round", prophecy.ID, pastRound.Round)
+		}
+	}
+
+	if prophecy.Status.Text == PendingStatusText && prophecy.Status.Resolved {
+		return fmt.Errorf("pending prophecy %s cannot have been resolved", prophecy.ID)
+	}
+	switch prophecy.Status.Text {
+	case SuccessStatusText:
+		// governance may resolve a prophecy with a final claim no validator made
+		if !prophecy.Status.Resolved && !prophecy.hasClaim(prophecy.Status.FinalClaim) {
+			return fmt.Errorf("successful prophecy %s has a final claim no validator made", prophecy.ID)
+		}
+	default:
+		if prophecy.Status.FinalClaim != "" {
+			return fmt.Errorf("%s prophecy %s cannot have a final claim", prophecy.Status.Text, prophecy.ID)
+		}
+	}
+
+	return nil
+}
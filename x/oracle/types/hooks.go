@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OracleHooks are called by the oracle keeper whenever a prophecy is finalized, however it was finalized, so
+// that the modules consuming prophecies act on them in a single place. Hooks are called for the prophecies of all
+// namespaces, each module must ignore the prophecies of namespaces other than its own.
+type OracleHooks interface {
+	// AfterProphecySucceeded is called once a prophecy reached consensus on its final claim
+	AfterProphecySucceeded(ctx sdk.Context, namespace, id string, finalClaim string) error
+	// AfterProphecyFailed is called once a prophecy failed or expired without reaching consensus
+	AfterProphecyFailed(ctx sdk.Context, namespace, id string, status StatusText) error
+}
+
+// MultiOracleHooks combines multiple oracle hooks, all hook functions are run in array sequence
+type MultiOracleHooks []OracleHooks
+
+var _ OracleHooks = MultiOracleHooks{}
+
+// NewMultiOracleHooks returns the given hooks combined
+func NewMultiOracleHooks(hooks ...OracleHooks) MultiOracleHooks {
+	return hooks
+}
+
+// AfterProphecySucceeded implements the OracleHooks interface, stopping at the first hook that fails
+func (h MultiOracleHooks) AfterProphecySucceeded(ctx sdk.Context, namespace, id string, finalClaim string) error {
+	for _, hooks := range h {
+		if err := hooks.AfterProphecySucceeded(ctx, namespace, id, finalClaim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterProphecyFailed implements the OracleHooks interface, stopping at the first hook that fails
+func (h MultiOracleHooks) AfterProphecyFailed(ctx sdk.Context, namespace, id string, status StatusText) error {
+	for _, hooks := range h {
+		if err := hooks.AfterProphecyFailed(ctx, namespace, id, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
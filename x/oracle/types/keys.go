@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the oracle module
 	ModuleName = "oracle"
@@ -13,3 +19,279 @@ const (
 	// RouterKey is the msg router key for the oracle module
 	RouterKey = ModuleName
 )
+
+// MaxProphecyIDLength is the maximum length of a scoped prophecy id, it must fit the single byte length prefix of
+// the claim keys
+const MaxProphecyIDLength = 255
+
+// Prophecy data is stored under the prefixes below, which all start with a control character. Before prophecies
+// were stored under ProphecyKeyPrefix they were stored under their raw id, ids must therefore start with a
+// printable character so that these legacy records sort after LegacyProphecyKeyStart. The ids of the keys below are
+// the scoped ids of the prophecies, which include their namespace.
+var (
+	// LegacyProphecyKeyStart is the lowest key a prophecy was stored under before the store was prefixed
+	LegacyProphecyKeyStart = []byte{0x20}
+
+	// ValidatorSetChangedKey is set when the validator set changed since pending prophecies were last tallied. Its
+	// value is the status index key of the next pending prophecy to re-tally, or empty to start from the first.
+	ValidatorSetChangedKey = []byte{0x01}
+
+	// ProphecyKeyPrefix is the prefix of the prophecies, without their claims
+	ProphecyKeyPrefix = []byte{0x02}
+
+	// ClaimKeyPrefix is the prefix of the claims, stored by prophecy and validator address
+	ClaimKeyPrefix = []byte{0x03}
+
+	// ProphecyStatusIndexPrefix is the prefix of the index of prophecies by status and creation height
+	ProphecyStatusIndexPrefix = []byte{0x04}
+
+	// ProphecyCreationHeightIndexPrefix is the prefix of the index of prophecies by creation height
+	ProphecyCreationHeightIndexPrefix = []byte{0x05}
+
+	// ValidatorPowerKeyPrefix is the prefix of the validator set snapshots, stored by prophecy and validator address
+	ValidatorPowerKeyPrefix = []byte{0x06}
+
+	// MisbehaviorKeyPrefix is the prefix of the misbehavior records, stored by validator address and prophecy
+	MisbehaviorKeyPrefix = []byte{0x07}
+
+	// ValidatorLivenessKeyPrefix is the prefix of the liveness records, stored by validator address
+	ValidatorLivenessKeyPrefix = []byte{0x08}
+
+	// MissedClaimKeyPrefix is the prefix of the claim windows, stored by validator address and window index. Only the
+	// indexes of missed claims are stored.
+	MissedClaimKeyPrefix = []byte{0x09}
+
+	// CommitKeyPrefix is the prefix of the claim commitments, stored by prophecy id and validator address
+	CommitKeyPrefix = []byte{0x0a}
+
+	// RejectionKeyPrefix is the prefix of the rejection votes, stored by prophecy id and validator address
+	RejectionKeyPrefix = []byte{0x0b}
+
+	// PastRoundKeyPrefix is the prefix of the past rounds of reopened prophecies, stored by prophecy id and round
+	PastRoundKeyPrefix = []byte{0x0c}
+
+	// ReferenceKeyPrefix is the prefix of the index of prophecies by the references of their claims, stored by
+	// namespace-scoped reference and prophecy id
+	ReferenceKeyPrefix = []byte{0x0d}
+
+	// LivenessQueueKeyPrefix is the prefix of the queue of finalized prophecies not yet counted in the claim windows
+	// of all the validators of their snapshot, stored by finalized height and prophecy id. The value of an entry is
+	// the address of the next validator to count, or empty to start from the first.
+	LivenessQueueKeyPrefix = []byte{0x0e}
+
+	// ProphecyNamespaceStatusIndexPrefix is the prefix of the index of prophecies by status and scoped id, which
+	// starts with their namespace, so that the prophecies of a namespace with a status are read without scanning
+	// those of the other namespaces
+	ProphecyNamespaceStatusIndexPrefix = []byte{0x0f}
+)
+
+// ValidateProphecyID returns an error if the given id cannot be used to store a prophecy in the namespace
+func ValidateProphecyID(namespace, id string) error {
+	if id == "" || id[0] < LegacyProphecyKeyStart[0] || len(ScopedProphecyID(namespace, id)) > MaxProphecyIDLength {
+		return ErrInvalidIdentifier
+	}
+	return nil
+}
+
+// ProphecyKey returns the key of the prophecy with the given id
+func ProphecyKey(id string) []byte {
+	return append(append([]byte{}, ProphecyKeyPrefix...), []byte(id)...)
+}
+
+// SplitProphecyKey returns the prophecy id of a prophecy key
+func SplitProphecyKey(key []byte) string {
+	return string(key[len(ProphecyKeyPrefix):])
+}
+
+// ClaimsKey returns the prefix of the claims made on the prophecy with the given id
+func ClaimsKey(id string) []byte {
+	return lengthPrefixedIDKey(ClaimKeyPrefix, id)
+}
+
+// ClaimKey returns the key of the claim made by the validator on the prophecy with the given id
+func ClaimKey(id string, validator sdk.ValAddress) []byte {
+	return append(ClaimsKey(id), validator.Bytes()...)
+}
+
+// SplitClaimKey returns the validator address of a claim key
+func SplitClaimKey(key []byte) sdk.ValAddress {
+	idLength := int(key[len(ClaimKeyPrefix)])
+	return sdk.ValAddress(key[len(ClaimKeyPrefix)+1+idLength:])
+}
+
+// CommitsKey returns the prefix of the claim commitments made on the prophecy with the given id
+func CommitsKey(id string) []byte {
+	return lengthPrefixedIDKey(CommitKeyPrefix, id)
+}
+
+// CommitKey returns the key of the claim commitment made by the validator on the prophecy with the given id
+func CommitKey(id string, validator sdk.ValAddress) []byte {
+	return append(CommitsKey(id), validator.Bytes()...)
+}
+
+// SplitCommitKey returns the validator address of a commit key
+func SplitCommitKey(key []byte) sdk.ValAddress {
+	idLength := int(key[len(CommitKeyPrefix)])
+	return sdk.ValAddress(key[len(CommitKeyPrefix)+1+idLength:])
+}
+
+// RejectionsKey returns the prefix of the rejection votes cast on the prophecy with the given id
+func RejectionsKey(id string) []byte {
+	return lengthPrefixedIDKey(RejectionKeyPrefix, id)
+}
+
+// RejectionKey returns the key of the rejection vote cast by the validator on the prophecy with the given id
+func RejectionKey(id string, validator sdk.ValAddress) []byte {
+	return append(RejectionsKey(id), validator.Bytes()...)
+}
+
+// SplitRejectionKey returns the validator address of a rejection key
+func SplitRejectionKey(key []byte) sdk.ValAddress {
+	idLength := int(key[len(RejectionKeyPrefix)])
+	return sdk.ValAddress(key[len(RejectionKeyPrefix)+1+idLength:])
+}
+
+// PastRoundsKey returns the prefix of the past rounds of the prophecy with the given id
+func PastRoundsKey(id string) []byte {
+	return lengthPrefixedIDKey(PastRoundKeyPrefix, id)
+}
+
+// PastRoundKey returns the key of a past round of the prophecy with the given id
+func PastRoundKey(id string, round uint64) []byte {
+	return append(PastRoundsKey(id), sdk.Uint64ToBigEndian(round)...)
+}
+
+// ReferencesKey returns the prefix of the index entries of the prophecies whose claims carry the given reference in
+// the namespace
+func ReferencesKey(namespace, reference string) []byte {
+	return lengthPrefixedIDKey(ReferenceKeyPrefix, ScopedProphecyID(namespace, reference))
+}
+
+// ReferenceKey returns the index key of the prophecy with the given id whose claims carry the given reference in the
+// namespace
+func ReferenceKey(namespace, reference, id string) []byte {
+	return append(ReferencesKey(namespace, reference), []byte(id)...)
+}
+
+// SplitReferenceKey returns the prophecy id of a reference index key
+func SplitReferenceKey(key []byte) string {
+	referenceLength := int(key[len(ReferenceKeyPrefix)])
+	return string(key[len(ReferenceKeyPrefix)+1+referenceLength:])
+}
+
+// ValidatorPowersKey returns the prefix of the validator set snapshot of the prophecy with the given id
+func ValidatorPowersKey(id string) []byte {
+	return lengthPrefixedIDKey(ValidatorPowerKeyPrefix, id)
+}
+
+// ValidatorPowerKey returns the key of the power of the validator in the snapshot of the prophecy with the given id
+func ValidatorPowerKey(id string, validator sdk.ValAddress) []byte {
+	return append(ValidatorPowersKey(id), validator.Bytes()...)
+}
+
+// MisbehaviorsByValidatorKey returns the prefix of the misbehavior records of a validator
+func MisbehaviorsByValidatorKey(validator sdk.ValAddress) []byte {
+	key := append(append([]byte{}, MisbehaviorKeyPrefix...), byte(len(validator)))
+	return append(key, validator.Bytes()...)
+}
+
+// MisbehaviorKey returns the key of the misbehavior record of a validator on the prophecy with the given id
+func MisbehaviorKey(validator sdk.ValAddress, id string) []byte {
+	return append(MisbehaviorsByValidatorKey(validator), []byte(id)...)
+}
+
+// ValidatorLivenessKey returns the key of the liveness record of a validator
+func ValidatorLivenessKey(validator sdk.ValAddress) []byte {
+	return append(append([]byte{}, ValidatorLivenessKeyPrefix...), validator.Bytes()...)
+}
+
+// MissedClaimsByValidatorKey returns the prefix of the missed claims of a validator
+func MissedClaimsByValidatorKey(validator sdk.ValAddress) []byte {
+	key := append(append([]byte{}, MissedClaimKeyPrefix...), byte(len(validator)))
+	return append(key, validator.Bytes()...)
+}
+
+// MissedClaimKey returns the key of a missed claim of a validator at an index of its claim window
+func MissedClaimKey(validator sdk.ValAddress, index int64) []byte {
+	return append(MissedClaimsByValidatorKey(validator), sdk.Uint64ToBigEndian(uint64(index))...)
+}
+
+// SplitMissedClaimKey returns the validator address and the window index of a missed claim key
+func SplitMissedClaimKey(key []byte) (sdk.ValAddress, int64) {
+	validatorLength := int(key[len(MissedClaimKeyPrefix)])
+	validatorStart := len(MissedClaimKeyPrefix) + 1
+	validator := sdk.ValAddress(key[validatorStart : validatorStart+validatorLength])
+	return validator, int64(binary.BigEndian.Uint64(key[validatorStart+validatorLength:]))
+}
+
+// LivenessQueueKey returns the liveness queue key of the prophecy with the given id finalized at a height
+func LivenessQueueKey(height int64, id string) []byte {
+	key := append(append([]byte{}, LivenessQueueKeyPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, []byte(id)...)
+}
+
+// SplitLivenessQueueKey returns the prophecy id of a liveness queue key
+func SplitLivenessQueueKey(key []byte) string {
+	return string(key[len(LivenessQueueKeyPrefix)+8:])
+}
+
+// lengthPrefixedIDKey returns the prefix followed by the length of the id and the id, so that the keys of a prophecy
+// never share a prefix with the keys of another prophecy whose id starts with the same characters
+func lengthPrefixedIDKey(prefix []byte, id string) []byte {
+	key := append(append([]byte{}, prefix...), byte(len(id)))
+	return append(key, []byte(id)...)
+}
+
+// ProphecyStatusIndexByStatusKey returns the prefix of the status index entries of prophecies with a status
+func ProphecyStatusIndexByStatusKey(status StatusText) []byte {
+	return append(append([]byte{}, ProphecyStatusIndexPrefix...), byte(status))
+}
+
+// ProphecyStatusIndexByHeightKey returns the prefix of the status index entries of prophecies with a status that
+// were created at a height
+func ProphecyStatusIndexByHeightKey(status StatusText, height int64) []byte {
+	return append(ProphecyStatusIndexByStatusKey(status), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// ProphecyStatusIndexKey returns the status index key of a prophecy
+func ProphecyStatusIndexKey(status StatusText, height int64, id string) []byte {
+	return append(ProphecyStatusIndexByHeightKey(status, height), []byte(id)...)
+}
+
+// SplitProphecyStatusIndexKey returns the prophecy id of a status index key
+func SplitProphecyStatusIndexKey(key []byte) string {
+	return string(key[len(ProphecyStatusIndexPrefix)+1+8:])
+}
+
+// ProphecyNamespaceStatusIndexByStatusKey returns the prefix of the namespace status index entries of prophecies
+// with a status in the namespace, or in all namespaces when it is empty
+func ProphecyNamespaceStatusIndexByStatusKey(status StatusText, namespace string) []byte {
+	key := append(append([]byte{}, ProphecyNamespaceStatusIndexPrefix...), byte(status))
+	return append(key, []byte(ScopedProphecyID(namespace, ""))...)
+}
+
+// ProphecyNamespaceStatusIndexKey returns the namespace status index key of the prophecy with the given scoped id
+func ProphecyNamespaceStatusIndexKey(status StatusText, id string) []byte {
+	return append(ProphecyNamespaceStatusIndexByStatusKey(status, ""), []byte(id)...)
+}
+
+// SplitProphecyNamespaceStatusIndexKey returns the prophecy id of a namespace status index key
+func SplitProphecyNamespaceStatusIndexKey(key []byte) string {
+	return string(key[len(ProphecyNamespaceStatusIndexPrefix)+1:])
+}
+
+// ProphecyCreationHeightIndexByHeightKey returns the prefix of the creation height index entries of prophecies
+// created at a height
+func ProphecyCreationHeightIndexByHeightKey(height int64) []byte {
+	return append(append([]byte{}, ProphecyCreationHeightIndexPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// ProphecyCreationHeightIndexKey returns the creation height index key of a prophecy
+func ProphecyCreationHeightIndexKey(height int64, id string) []byte {
+	return append(ProphecyCreationHeightIndexByHeightKey(height), []byte(id)...)
+}
+
+// SplitProphecyCreationHeightIndexKey returns the prophecy id of a creation height index key
+func SplitProphecyCreationHeightIndexKey(key []byte) string {
+	return string(key[len(ProphecyCreationHeightIndexPrefix)+8:])
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LegacyDBProphecy is how prophecies were stored under their raw id before the store was prefixed. Its claims
+// are serialized as json maps from a claim to the validators that made it and from a validator bech32 address to
+// its claim.
+type LegacyDBProphecy struct {
+	ID              string `json:"id"`
+	Status          Status `json:"status"`
+	ClaimValidators []byte `json:"claim_validators"`
+	ValidatorClaims []byte `json:"validator_claims"`
+}
+
+// ToProphecy converts a LegacyDBProphecy into a prophecy created, and finalized unless it is pending, at the given
+// height. Claims are taken from the validator claims map, the claim validators map only indexes the same claims by
+// value.
+func (legacyProphecy LegacyDBProphecy) ToProphecy(creationHeight int64) (Prophecy, error) {
+	var validatorClaims map[string]string
+	if err := json.Unmarshal(legacyProphecy.ValidatorClaims, &validatorClaims); err != nil {
+		return Prophecy{}, err
+	}
+
+	prophecy := Prophecy{
+		ID:             legacyProphecy.ID,
+		Status:         legacyProphecy.Status,
+		CreationHeight: creationHeight,
+	}
+	if prophecy.Status.Text != PendingStatusText {
+		prophecy.FinalizedHeight = creationHeight
+	}
+	for validatorBech32, claim := range validatorClaims {
+		validator, err := sdk.ValAddressFromBech32(validatorBech32)
+		if err != nil {
+			return Prophecy{}, err
+		}
+		// AddClaim keeps the claims ordered whatever order the map is iterated in. Legacy claims did not record the
+		// height they were made at.
+		prophecy.AddClaim(validator, claim, creationHeight)
+	}
+
+	return prophecy, nil
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorLiveness tracks the claims a validator missed over the last ClaimWindow finalized prophecies of its
+// validator set snapshots, the way x/slashing tracks missed blocks
+type ValidatorLiveness struct {
+	Validator sdk.ValAddress `json:"validator"`
+	// IndexOffset is the number of finalized prophecies counted since the window was last reset, the position of a
+	// prophecy in the window is its offset modulo the claim window
+	IndexOffset int64 `json:"index_offset"`
+	// MissedClaimsCounter is the number of claims missed in the window
+	MissedClaimsCounter int64 `json:"missed_claims_counter"`
+}
+
+// NewValidatorLiveness returns a new ValidatorLiveness
+func NewValidatorLiveness(validator sdk.ValAddress, indexOffset, missedClaimsCounter int64) ValidatorLiveness {
+	return ValidatorLiveness{
+		Validator:           validator,
+		IndexOffset:         indexOffset,
+		MissedClaimsCounter: missedClaimsCounter,
+	}
+}
+
+// Validate performs basic validation of a liveness record
+func (liveness ValidatorLiveness) Validate() error {
+	if liveness.Validator.Empty() {
+		return fmt.Errorf("liveness record has no validator")
+	}
+	if liveness.IndexOffset < 0 || liveness.MissedClaimsCounter < 0 {
+		return fmt.Errorf("liveness record of %s has a negative counter", liveness.Validator)
+	}
+	return nil
+}
+
+// MissedClaim is the position in the claim window of a finalized prophecy a validator did not claim on
+type MissedClaim struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Index     int64          `json:"index"`
+}
+
+// NewMissedClaim returns a new MissedClaim
+func NewMissedClaim(validator sdk.ValAddress, index int64) MissedClaim {
+	return MissedClaim{
+		Validator: validator,
+		Index:     index,
+	}
+}
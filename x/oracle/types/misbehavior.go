@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Misbehavior records a claim of a validator that contradicts the final claim of a successful prophecy
+type Misbehavior struct {
+	ProphecyID string         `json:"prophecy_id"`
+	Validator  sdk.ValAddress `json:"validator"`
+	Claim      string         `json:"claim"`
+	FinalClaim string         `json:"final_claim"`
+	// Height is the height at which the prophecy succeeded
+	Height int64 `json:"height"`
+	// Namespace is the namespace of the prophecy, last so that records stored before it was added still decode
+	Namespace string `json:"namespace"`
+}
+
+// NewMisbehavior returns a new Misbehavior
+func NewMisbehavior(
+	namespace, prophecyID string, validator sdk.ValAddress, claim, finalClaim string, height int64,
+) Misbehavior {
+	return Misbehavior{
+		Namespace:  namespace,
+		ProphecyID: prophecyID,
+		Validator:  validator,
+		Claim:      claim,
+		FinalClaim: finalClaim,
+		Height:     height,
+	}
+}
+
+// Validate performs basic validation of a misbehavior record
+func (misbehavior Misbehavior) Validate() error {
+	// records without a namespace predate namespaces, they are moved into the namespace of their consumer
+	if misbehavior.Namespace != "" {
+		if err := ValidateNamespace(misbehavior.Namespace); err != nil {
+			return err
+		}
+	}
+	if err := ValidateProphecyID(misbehavior.Namespace, misbehavior.ProphecyID); err != nil {
+		return err
+	}
+	if misbehavior.Validator.Empty() {
+		return fmt.Errorf("misbehavior on prophecy %s has no validator", misbehavior.ProphecyID)
+	}
+	if misbehavior.Claim == "" || misbehavior.FinalClaim == "" {
+		return fmt.Errorf("misbehavior of %s on prophecy %s has an empty claim", misbehavior.Validator,
+			misbehavior.ProphecyID)
+	}
+	if misbehavior.Claim == misbehavior.FinalClaim {
+		return fmt.Errorf("misbehavior of %s on prophecy %s matches the final claim", misbehavior.Validator,
+			misbehavior.ProphecyID)
+	}
+	if misbehavior.Height < 0 {
+		return fmt.Errorf("misbehavior of %s on prophecy %s has a negative height", misbehavior.Validator,
+			misbehavior.ProphecyID)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxNamespaceLength is the maximum length of a namespace
+const MaxNamespaceLength = 32
+
+// namespaceSeparator separates the namespace of a prophecy from its id in the id it is stored under. Namespaces
+// cannot contain it, so the scoped ids of two namespaces never collide.
+const namespaceSeparator = "/"
+
+// AggregationMode defines how the claims made on a prophecy are aggregated into its final claim
+type AggregationMode byte
+
+const (
+	// ExactMatchAggregation finalizes a prophecy on the claim with the highest power, once that power reaches the
+	// consensus needed
+	ExactMatchAggregation AggregationMode = iota
+	// WeightedMedianAggregation finalizes a prophecy of numeric claims on their power-weighted median, once the
+	// power of all claims made reaches the consensus needed
+	WeightedMedianAggregation
+)
+
+// ClaimContentType defines the claim content of a namespace. It is registered with the oracle keeper by the module
+// consuming the prophecies of the namespace.
+type ClaimContentType interface {
+	// NormalizeContent validates the content of a claim and returns it in canonical form. Claims are tallied by
+	// their canonical content, so two claims are equal when their canonical contents are.
+	NormalizeContent(content string) (string, error)
+	// AggregationMode returns how the claims of the namespace's prophecies are aggregated
+	AggregationMode() AggregationMode
+}
+
+// IndexedClaimContentType is implemented by the claim content types whose claims carry references to the external
+// records they attest, such as the hash of a transaction on another chain. Prophecies are indexed by the references
+// of their claims so that they can be looked up from those records.
+type IndexedClaimContentType interface {
+	ClaimContentType
+	// ContentReferences returns the references carried by a claim content in canonical form
+	ContentReferences(content string) []string
+}
+
+// StringContentType is a claim content type that accepts any non-empty string, claims being equal only when
+// their contents are identical
+type StringContentType struct{}
+
+var _ ClaimContentType = StringContentType{}
+
+// NormalizeContent implements the ClaimContentType interface
+func (StringContentType) NormalizeContent(content string) (string, error) {
+	if content == "" {
+		return "", ErrInvalidClaim
+	}
+	return content, nil
+}
+
+// AggregationMode implements the ClaimContentType interface
+func (StringContentType) AggregationMode() AggregationMode {
+	return ExactMatchAggregation
+}
+
+// DecimalContentType is a claim content type for numeric attestations, such as gas prices or exchange rates. Its
+// claims are decimal numbers with up to 18 decimal places, and its prophecies finalize on their power-weighted
+// median.
+type DecimalContentType struct{}
+
+var _ ClaimContentType = DecimalContentType{}
+
+// NormalizeContent implements the ClaimContentType interface
+func (DecimalContentType) NormalizeContent(content string) (string, error) {
+	value, err := sdk.NewDecFromStr(content)
+	if err != nil {
+		return "", ErrInvalidClaim
+	}
+	return value.String(), nil
+}
+
+// AggregationMode implements the ClaimContentType interface
+func (DecimalContentType) AggregationMode() AggregationMode {
+	return WeightedMedianAggregation
+}
+
+// ValidateNamespace returns an error if the namespace is not a non-empty string of lowercase letters, digits and
+// underscores starting with a letter
+func ValidateNamespace(namespace string) error {
+	if namespace == "" || len(namespace) > MaxNamespaceLength || namespace[0] < 'a' || namespace[0] > 'z' {
+		return ErrInvalidNamespace
+	}
+	for _, c := range namespace {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return ErrInvalidNamespace
+		}
+	}
+	return nil
+}
+
+// ScopedProphecyID returns the id a prophecy is stored under, its namespace and id joined by a separator.
+// Prophecies stored before prophecies were namespaced have no namespace and are stored under their id.
+func ScopedProphecyID(namespace, id string) string {
+	if namespace == "" {
+		return id
+	}
+	return namespace + namespaceSeparator + id
+}
+
+// SplitScopedProphecyID returns the namespace and the id of a scoped prophecy id
+func SplitScopedProphecyID(scopedID string) (namespace, id string) {
+	i := strings.Index(scopedID, namespaceSeparator)
+	if i < 0 {
+		return "", scopedID
+	}
+	return scopedID[:i], scopedID[i+len(namespaceSeparator):]
+}
@@ -0,0 +1,459 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default oracle module parameter subspace
+const DefaultParamspace = ModuleName
+
+// DefaultConsensusNeeded defines the default consensus value required for a
+// prophecy to be finalized
+var DefaultConsensusNeeded = sdk.NewDecWithPrec(7, 1)
+
+const (
+	// DefaultProphecyExpiryBlocks defines the default number of blocks after which a pending prophecy expires,
+	// about a week of 6 second blocks
+	DefaultProphecyExpiryBlocks int64 = 100800
+
+	// DefaultMaxExpiriesPerBlock defines the default maximum number of prophecies expired in a single block
+	DefaultMaxExpiriesPerBlock uint64 = 100
+
+	// DefaultMaxRetalliesPerBlock defines the default maximum number of pending prophecies re-tallied in a single
+	// block after validator set changes
+	DefaultMaxRetalliesPerBlock uint64 = 100
+
+	// DefaultMisbehaviorPolicy defines the default action taken against validators whose claims contradict the
+	// final claim of a prophecy
+	DefaultMisbehaviorPolicy = MisbehaviorPolicyEvidence
+
+	// DefaultMisbehaviorJailDuration defines the default duration a validator is jailed for a contradicting claim
+	DefaultMisbehaviorJailDuration = 10 * time.Minute
+
+	// DefaultClaimWindow defines the default number of finalized prophecies over which missed claims are counted
+	DefaultClaimWindow int64 = 100
+
+	// DefaultLivenessJailDuration defines the default duration a validator is jailed for missing too many claims
+	DefaultLivenessJailDuration = 10 * time.Minute
+
+	// DefaultMaxLivenessUpdatesPerBlock defines the default maximum number of validators whose claim window is
+	// updated in a single block for the prophecies finalized before it
+	DefaultMaxLivenessUpdatesPerBlock uint64 = 10000
+
+	// DefaultCommitWindowBlocks defines the default number of blocks during which the claims of a new prophecy are
+	// committed. It is zero, so claims are made without being committed first by default.
+	DefaultCommitWindowBlocks int64 = 0
+)
+
+// Misbehavior policies, the action taken against validators whose claims contradict the final claim of a prophecy
+const (
+	// MisbehaviorPolicyEvidence only records the misbehavior and emits it as an event
+	MisbehaviorPolicyEvidence = "evidence"
+	// MisbehaviorPolicySlash also slashes and jails the validator through the slashing keeper
+	MisbehaviorPolicySlash = "slash"
+)
+
+// DefaultMisbehaviorSlashFraction defines the default fraction of a validator's stake slashed for a contradicting
+// claim
+var DefaultMisbehaviorSlashFraction = sdk.NewDecWithPrec(1, 2)
+
+// DefaultMinClaimedPerWindow defines the default minimum proportion of the claim window a validator must have
+// claimed on to avoid being jailed. It is zero, so validators are not jailed for missed claims by default.
+var DefaultMinClaimedPerWindow = sdk.ZeroDec()
+
+// DefaultRejectionThreshold defines the default proportion of the power of a prophecy's validator set snapshot that
+// must vote to reject it for it to fail, a third
+var DefaultRejectionThreshold = sdk.OneDec().QuoInt64(3)
+
+// DefaultReopenCooldownBlocks defines the default number of blocks after a prophecy failed or expired before it can
+// be reopened for a new round. It is zero, so prophecies are not reopened by default.
+const DefaultReopenCooldownBlocks int64 = 0
+
+// Parameter store keys
+var (
+	KeyConsensusNeeded      = []byte("ConsensusNeeded")
+	KeyProphecyExpiryBlocks = []byte("ProphecyExpiryBlocks")
+	KeyMaxExpiriesPerBlock  = []byte("MaxExpiriesPerBlock")
+	KeyMaxRetalliesPerBlock = []byte("MaxRetalliesPerBlock")
+
+	KeyMisbehaviorPolicy        = []byte("MisbehaviorPolicy")
+	KeyMisbehaviorSlashFraction = []byte("MisbehaviorSlashFraction")
+	KeyMisbehaviorJailDuration  = []byte("MisbehaviorJailDuration")
+
+	KeyClaimWindow                = []byte("ClaimWindow")
+	KeyMinClaimedPerWindow        = []byte("MinClaimedPerWindow")
+	KeyLivenessJailDuration       = []byte("LivenessJailDuration")
+	KeyMaxLivenessUpdatesPerBlock = []byte("MaxLivenessUpdatesPerBlock")
+
+	KeyCommitWindowBlocks = []byte("CommitWindowBlocks")
+	KeyRejectionThreshold = []byte("RejectionThreshold")
+
+	KeyReopenCooldownBlocks = []byte("ReopenCooldownBlocks")
+)
+
+var _ params.ParamSet = (*Params)(nil)
+
+// Params defines the parameters for the oracle module
+type Params struct {
+	// The minimum proportion of bonded stake needed to sign claims in order for consensus to occur
+	ConsensusNeeded sdk.Dec `json:"consensus_needed" yaml:"consensus_needed"`
+	// The number of blocks after its creation at which a prophecy that is still pending expires
+	ProphecyExpiryBlocks int64 `json:"prophecy_expiry_blocks" yaml:"prophecy_expiry_blocks"`
+	// The maximum number of prophecies expired in a single block, the rest are expired in the following blocks
+	MaxExpiriesPerBlock uint64 `json:"max_expiries_per_block" yaml:"max_expiries_per_block"`
+	// The maximum number of pending prophecies re-tallied in a single block after validator set changes, the rest
+	// are re-tallied in the following blocks
+	MaxRetalliesPerBlock uint64 `json:"max_retallies_per_block" yaml:"max_retallies_per_block"`
+	// The action taken against validators whose claims contradict the final claim of a successful prophecy,
+	// either evidence or slash
+	MisbehaviorPolicy string `json:"misbehavior_policy" yaml:"misbehavior_policy"`
+	// The fraction of stake slashed for a contradicting claim under the slash policy
+	MisbehaviorSlashFraction sdk.Dec `json:"misbehavior_slash_fraction" yaml:"misbehavior_slash_fraction"`
+	// The duration a validator is jailed for a contradicting claim under the slash policy
+	MisbehaviorJailDuration time.Duration `json:"misbehavior_jail_duration" yaml:"misbehavior_jail_duration"`
+	// The number of finalized prophecies of its validator set snapshot over which a validator's missed claims are
+	// counted
+	ClaimWindow int64 `json:"claim_window" yaml:"claim_window"`
+	// The minimum proportion of the claim window a validator must have claimed on, validators missing more claims
+	// are jailed. Zero disables jailing for missed claims.
+	MinClaimedPerWindow sdk.Dec `json:"min_claimed_per_window" yaml:"min_claimed_per_window"`
+	// The duration a validator is jailed for missing too many claims
+	LivenessJailDuration time.Duration `json:"liveness_jail_duration" yaml:"liveness_jail_duration"`
+	// The maximum number of validators whose claim window is updated in a single block for the prophecies finalized
+	// before it, the rest are updated in the following blocks
+	MaxLivenessUpdatesPerBlock uint64 `json:"max_liveness_updates_per_block" yaml:"max_liveness_updates_per_block"`
+	// The number of blocks after its creation during which validators commit to their claims on a prophecy, their
+	// claims are revealed and tallied once it closes. Zero disables commit-reveal voting for new prophecies.
+	CommitWindowBlocks int64 `json:"commit_window_blocks" yaml:"commit_window_blocks"`
+	// The minimum proportion of the power of a prophecy's validator set snapshot that must vote to reject it for
+	// the prophecy to fail before it can no longer succeed
+	RejectionThreshold sdk.Dec `json:"rejection_threshold" yaml:"rejection_threshold"`
+	// The number of blocks after a prophecy failed or expired from which a new claim or commitment on it opens a new
+	// voting round. Zero disables reopening prophecies.
+	ReopenCooldownBlocks int64 `json:"reopen_cooldown_blocks" yaml:"reopen_cooldown_blocks"`
+}
+
+// ParamKeyTable returns the key declaration for the oracle module parameters
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params instance
+func NewParams(
+	consensusNeeded sdk.Dec, prophecyExpiryBlocks int64, maxExpiriesPerBlock, maxRetalliesPerBlock uint64,
+	misbehaviorPolicy string, misbehaviorSlashFraction sdk.Dec, misbehaviorJailDuration time.Duration,
+	claimWindow int64, minClaimedPerWindow sdk.Dec, livenessJailDuration time.Duration,
+	maxLivenessUpdatesPerBlock uint64, commitWindowBlocks int64, rejectionThreshold sdk.Dec,
+	reopenCooldownBlocks int64,
+) Params {
+	return Params{
+		ConsensusNeeded:            consensusNeeded,
+		ProphecyExpiryBlocks:       prophecyExpiryBlocks,
+		MaxExpiriesPerBlock:        maxExpiriesPerBlock,
+		MaxRetalliesPerBlock:       maxRetalliesPerBlock,
+		MisbehaviorPolicy:          misbehaviorPolicy,
+		MisbehaviorSlashFraction:   misbehaviorSlashFraction,
+		MisbehaviorJailDuration:    misbehaviorJailDuration,
+		ClaimWindow:                claimWindow,
+		MinClaimedPerWindow:        minClaimedPerWindow,
+		LivenessJailDuration:       livenessJailDuration,
+		MaxLivenessUpdatesPerBlock: maxLivenessUpdatesPerBlock,
+		CommitWindowBlocks:         commitWindowBlocks,
+		RejectionThreshold:         rejectionThreshold,
+		ReopenCooldownBlocks:       reopenCooldownBlocks,
+	}
+}
+
+// DefaultParams returns the default parameters for the oracle module
+func DefaultParams() Params {
+	return NewParams(
+		DefaultConsensusNeeded, DefaultProphecyExpiryBlocks, DefaultMaxExpiriesPerBlock, DefaultMaxRetalliesPerBlock,
+		DefaultMisbehaviorPolicy, DefaultMisbehaviorSlashFraction, DefaultMisbehaviorJailDuration,
+		DefaultClaimWindow, DefaultMinClaimedPerWindow, DefaultLivenessJailDuration, DefaultMaxLivenessUpdatesPerBlock,
+		DefaultCommitWindowBlocks, DefaultRejectionThreshold, DefaultReopenCooldownBlocks,
+	)
+}
+
+// ParamSetPairs implements the params.ParamSet interface
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyConsensusNeeded, &p.ConsensusNeeded, validateConsensusNeeded),
+		params.NewParamSetPair(KeyProphecyExpiryBlocks, &p.ProphecyExpiryBlocks, validateProphecyExpiryBlocks),
+		params.NewParamSetPair(KeyMaxExpiriesPerBlock, &p.MaxExpiriesPerBlock, validateMaxExpiriesPerBlock),
+		params.NewParamSetPair(KeyMaxRetalliesPerBlock, &p.MaxRetalliesPerBlock, validateMaxRetalliesPerBlock),
+		params.NewParamSetPair(KeyMisbehaviorPolicy, &p.MisbehaviorPolicy, validateMisbehaviorPolicy),
+		params.NewParamSetPair(
+			KeyMisbehaviorSlashFraction, &p.MisbehaviorSlashFraction, validateMisbehaviorSlashFraction),
+		params.NewParamSetPair(KeyMisbehaviorJailDuration, &p.MisbehaviorJailDuration, validateMisbehaviorJailDuration),
+		params.NewParamSetPair(KeyClaimWindow, &p.ClaimWindow, validateClaimWindow),
+		params.NewParamSetPair(KeyMinClaimedPerWindow, &p.MinClaimedPerWindow, validateMinClaimedPerWindow),
+		params.NewParamSetPair(KeyLivenessJailDuration, &p.LivenessJailDuration, validateLivenessJailDuration),
+		params.NewParamSetPair(
+			KeyMaxLivenessUpdatesPerBlock, &p.MaxLivenessUpdatesPerBlock, validateMaxLivenessUpdatesPerBlock),
+		params.NewParamSetPair(KeyCommitWindowBlocks, &p.CommitWindowBlocks, validateCommitWindowBlocks),
+		params.NewParamSetPair(KeyRejectionThreshold, &p.RejectionThreshold, validateRejectionThreshold),
+		params.NewParamSetPair(KeyReopenCooldownBlocks, &p.ReopenCooldownBlocks, validateReopenCooldownBlocks),
+	}
+}
+
+// Validate performs basic validation of the oracle parameters
+func (p Params) Validate() error {
+	if err := validateConsensusNeeded(p.ConsensusNeeded); err != nil {
+		return err
+	}
+	if err := validateProphecyExpiryBlocks(p.ProphecyExpiryBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxExpiriesPerBlock(p.MaxExpiriesPerBlock); err != nil {
+		return err
+	}
+	if err := validateMaxRetalliesPerBlock(p.MaxRetalliesPerBlock); err != nil {
+		return err
+	}
+	if err := validateMisbehaviorPolicy(p.MisbehaviorPolicy); err != nil {
+		return err
+	}
+	if err := validateMisbehaviorSlashFraction(p.MisbehaviorSlashFraction); err != nil {
+		return err
+	}
+	if err := validateMisbehaviorJailDuration(p.MisbehaviorJailDuration); err != nil {
+		return err
+	}
+	if err := validateClaimWindow(p.ClaimWindow); err != nil {
+		return err
+	}
+	if err := validateMinClaimedPerWindow(p.MinClaimedPerWindow); err != nil {
+		return err
+	}
+	if err := validateLivenessJailDuration(p.LivenessJailDuration); err != nil {
+		return err
+	}
+	if err := validateMaxLivenessUpdatesPerBlock(p.MaxLivenessUpdatesPerBlock); err != nil {
+		return err
+	}
+	if err := validateCommitWindowBlocks(p.CommitWindowBlocks); err != nil {
+		return err
+	}
+	if err := validateRejectionThreshold(p.RejectionThreshold); err != nil {
+		return err
+	}
+	if err := validateReopenCooldownBlocks(p.ReopenCooldownBlocks); err != nil {
+		return err
+	}
+	if p.CommitWindowBlocks >= p.ProphecyExpiryBlocks {
+		return fmt.Errorf("commit window blocks %d must be less than prophecy expiry blocks %d", p.CommitWindowBlocks,
+			p.ProphecyExpiryBlocks)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface
+func (p Params) String() string {
+	return fmt.Sprintf(`Oracle Params:
+  Consensus Needed:           %s
+  Prophecy Expiry Blocks:     %d
+  Max Expiries Per Block:     %d
+  Max Retallies Per Block:    %d
+  Misbehavior Policy:         %s
+  Misbehavior Slash Fraction: %s
+  Misbehavior Jail Duration:  %s
+  Claim Window:               %d
+  Min Claimed Per Window:     %s
+  Liveness Jail Duration:     %s
+  Max Liveness Updates:       %d
+  Commit Window Blocks:       %d
+  Rejection Threshold:        %s
+  Reopen Cooldown Blocks:     %d`,
+		p.ConsensusNeeded, p.ProphecyExpiryBlocks, p.MaxExpiriesPerBlock, p.MaxRetalliesPerBlock,
+		p.MisbehaviorPolicy, p.MisbehaviorSlashFraction, p.MisbehaviorJailDuration,
+		p.ClaimWindow, p.MinClaimedPerWindow, p.LivenessJailDuration, p.MaxLivenessUpdatesPerBlock,
+		p.CommitWindowBlocks, p.RejectionThreshold, p.ReopenCooldownBlocks)
+}
+
+func validateConsensusNeeded(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() || v.GT(sdk.OneDec()) {
+		return ErrMinimumConsensusNeededInvalid
+	}
+
+	return nil
+}
+
+func validateProphecyExpiryBlocks(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("prophecy expiry blocks must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxExpiriesPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max expiries per block must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxRetalliesPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max retallies per block must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMisbehaviorPolicy(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	switch v {
+	case MisbehaviorPolicyEvidence, MisbehaviorPolicySlash:
+		return nil
+	default:
+		return fmt.Errorf("misbehavior policy must be %s or %s: %s", MisbehaviorPolicyEvidence,
+			MisbehaviorPolicySlash, v)
+	}
+}
+
+func validateMisbehaviorSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("misbehavior slash fraction must be between 0 and 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateMisbehaviorJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("misbehavior jail duration must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateClaimWindow(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("claim window must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMinClaimedPerWindow(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("min claimed per window must be between 0 and 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateLivenessJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("liveness jail duration must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateMaxLivenessUpdatesPerBlock(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max liveness updates per block must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateCommitWindowBlocks(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("commit window blocks must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateRejectionThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("rejection threshold must be greater than 0 and at most 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateReopenCooldownBlocks(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("reopen cooldown blocks must not be negative: %d", v)
+	}
+
+	return nil
+}
@@ -1,137 +1,374 @@
 package types
 
 import (
-	"encoding/json"
-
-	"github.com/cosmos/cosmos-sdk/x/staking"
+	"bytes"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// DefaultConsensusNeeded defines the default consensus value required for a
-// prophecy to be finalized
-const DefaultConsensusNeeded float64 = 0.7
-
 // Prophecy is a struct that contains all the metadata of an oracle ritual.
-// Claims are indexed by the claim's validator bech32 address and by the claim's json value to allow
-// for constant lookup times for any validation/verifiation checks of duplicate claims
-// Each transaction, pending potential results are also calculated, stored and indexed by their byte result
-// to allow discovery of consensus on any the result in constant time without having to sort or run
-// through the list of claims to find the one with highest consensus
+// Claims are kept as a list ordered by validator address, so that a prophecy is stored, exported and tallied the
+// same way on every node.
 type Prophecy struct {
-	ID     string `json:"id"`
-	Status Status `json:"status"`
-
-	//WARNING: Mappings are nondeterministic in Amino,
-	// an so iterating over them could result in consensus failure. New code should not iterate over the below 2 mappings.
-
-	//This is a mapping from a claim to the list of validators that made that claim.
-	ClaimValidators map[string][]sdk.ValAddress `json:"claim_validators"`
-	//This is a mapping from a validator bech32 address to their claim
-	ValidatorClaims map[string]string `json:"validator_claims"`
+	// Namespace is the namespace of the module consuming the prophecy, its id is unique within the namespace
+	Namespace      string `json:"namespace"`
+	ID             string `json:"id"`
+	Status         Status `json:"status"`
+	CreationHeight int64  `json:"creation_height"`
+	// ValidatorPowers is the bonded validator set and their powers at the time the prophecy was created, in
+	// validator address order. The prophecy is tallied against this snapshot only.
+	ValidatorPowers []ValidatorPower `json:"validator_powers"`
+	// Claims holds the claim of each validator that made one, in validator address order
+	Claims []ValidatorClaim `json:"claims"`
+	// CommitEndHeight is the height at which the commit window of the prophecy closes, zero if its claims are not
+	// committed before they are revealed
+	CommitEndHeight int64 `json:"commit_end_height"`
+	// Commits holds the claim commitment of each validator that made one, in validator address order
+	Commits []ValidatorCommit `json:"commits"`
+	// Rejections holds the validators that voted to reject the prophecy instead of claiming, in address order
+	Rejections []sdk.ValAddress `json:"rejections"`
+	// Round is the number of times the prophecy was reopened, the fields above are those of its current round
+	Round uint64 `json:"round"`
+	// FinalizedHeight is the height at which the current round was finalized, zero while it is pending
+	FinalizedHeight int64 `json:"finalized_height"`
+	// PastRounds holds the previous rounds of the prophecy in round order
+	PastRounds []ProphecyRound `json:"past_rounds"`
 }
 
-// DBProphecy is what the prophecy becomes when being saved to the database.
-//  Tendermint/Amino does not support maps so we must serialize those variables into bytes.
+// DBProphecy is what the prophecy becomes when being saved to the database. The claims and the validator set
+// snapshot are stored separately, one entry per validator. Instead, the prophecy keeps running tallies of the power
+// behind each claim, so that processing a claim only reads and writes the entries of its own validator however
+// large the validator set is.
 type DBProphecy struct {
-	ID              string `json:"id"`
-	Status          Status `json:"status"`
-	ClaimValidators []byte `json:"claim_validators"`
-	ValidatorClaims []byte `json:"validator_claims"`
+	ID             string `json:"id"`
+	Status         Status `json:"status"`
+	CreationHeight int64  `json:"creation_height"`
+	// TotalPower is the total power of the validator set snapshot, zero if the prophecy has no snapshot
+	TotalPower sdk.Int `json:"total_power"`
+	// RemainingPower is the power of the validators of the snapshot that can still make a claim
+	RemainingPower sdk.Int `json:"remaining_power"`
+	// ClaimPowers holds the power behind each claim made so far, in claim order
+	ClaimPowers []ClaimPower `json:"claim_powers"`
+	// Namespace is the namespace of the prophecy, last so that prophecies stored before it was added still decode
+	Namespace string `json:"namespace"`
+	// CommitEndHeight is the height at which the commit window of the prophecy closes, zero if it has none
+	CommitEndHeight int64 `json:"commit_end_height"`
+	// RejectedPower is the power of the validators that rejected the prophecy, see GetRejectedPower
+	RejectedPower sdk.Int `json:"rejected_power"`
+	// Round is the current round of the prophecy
+	Round uint64 `json:"round"`
+	// FinalizedHeight is the height at which the current round was finalized, zero while it is pending
+	FinalizedHeight int64 `json:"finalized_height"`
 }
 
-// SerializeForDB serializes a prophecy into a DBProphecy
-// TODO: Using gob here may mean that different tendermint clients in different languages may serialize/store
-// prophecies in their db in different ways -
-// check with @codereviewer if this is ok or if it introduces a risk of creating forks.
-// Or maybe using a slower json serializer or Amino:JSON would be ok
-func (prophecy Prophecy) SerializeForDB() (DBProphecy, error) {
-	claimValidators, err := json.Marshal(prophecy.ClaimValidators)
-	if err != nil {
-		return DBProphecy{}, err
-	}
-
-	validatorClaims, err := json.Marshal(prophecy.ValidatorClaims)
-	if err != nil {
-		return DBProphecy{}, err
-	}
+// ClaimPower is the total power of the validators that made a claim on a prophecy
+type ClaimPower struct {
+	Claim string  `json:"claim"`
+	Power sdk.Int `json:"power"`
+}
 
-	return DBProphecy{
+// ToDBProphecy returns the prophecy as it is stored in the database, with its tallies computed from its claims and
+// validator powers
+func (prophecy Prophecy) ToDBProphecy() DBProphecy {
+	dbProphecy := DBProphecy{
+		Namespace:       prophecy.Namespace,
 		ID:              prophecy.ID,
 		Status:          prophecy.Status,
-		ClaimValidators: claimValidators,
-		ValidatorClaims: validatorClaims,
-	}, nil
-}
-
-// DeserializeFromDB deserializes a DBProphecy into a prophecy
-func (dbProphecy DBProphecy) DeserializeFromDB() (Prophecy, error) {
-	var claimValidators map[string][]sdk.ValAddress
-	if err := json.Unmarshal(dbProphecy.ClaimValidators, &claimValidators); err != nil {
-		return Prophecy{}, err
+		CreationHeight:  prophecy.CreationHeight,
+		TotalPower:      sdk.ZeroInt(),
+		RemainingPower:  sdk.ZeroInt(),
+		CommitEndHeight: prophecy.CommitEndHeight,
+		RejectedPower:   sdk.ZeroInt(),
+		Round:           prophecy.Round,
+		FinalizedHeight: prophecy.FinalizedHeight,
 	}
-
-	var validatorClaims map[string]string
-	if err := json.Unmarshal(dbProphecy.ValidatorClaims, &validatorClaims); err != nil {
-		return Prophecy{}, err
+	for _, validatorPower := range prophecy.ValidatorPowers {
+		dbProphecy.TotalPower = dbProphecy.TotalPower.AddRaw(validatorPower.Power)
+		if prophecy.HasRejected(validatorPower.Validator) {
+			dbProphecy.RejectedPower = dbProphecy.RejectedPower.AddRaw(validatorPower.Power)
+			continue
+		}
+		if _, claimed := prophecy.GetClaim(validatorPower.Validator); !claimed && !validatorPower.Forfeited {
+			dbProphecy.RemainingPower = dbProphecy.RemainingPower.AddRaw(validatorPower.Power)
+		}
+	}
+	for _, validatorClaim := range prophecy.Claims {
+		power, _ := prophecy.GetValidatorPower(validatorClaim.Validator)
+		dbProphecy.AddClaimPower(validatorClaim.Content, power)
 	}
+	return dbProphecy
+}
 
+// ToProphecy returns the prophecy stored as the DBProphecy with the given validator powers, claims, commits and
+// rejections, without its past rounds
+func (dbProphecy DBProphecy) ToProphecy(
+	validatorPowers []ValidatorPower, claims []ValidatorClaim, commits []ValidatorCommit, rejections []sdk.ValAddress,
+) Prophecy {
 	return Prophecy{
+		Namespace:       dbProphecy.Namespace,
 		ID:              dbProphecy.ID,
 		Status:          dbProphecy.Status,
-		ClaimValidators: claimValidators,
-		ValidatorClaims: validatorClaims,
-	}, nil
+		CreationHeight:  dbProphecy.CreationHeight,
+		ValidatorPowers: validatorPowers,
+		Claims:          claims,
+		CommitEndHeight: dbProphecy.CommitEndHeight,
+		Commits:         commits,
+		Rejections:      rejections,
+		Round:           dbProphecy.Round,
+		FinalizedHeight: dbProphecy.FinalizedHeight,
+	}
 }
 
-// AddClaim adds a given claim to this prophecy
-func (prophecy Prophecy) AddClaim(validator sdk.ValAddress, claim string) {
-	claimValidators := prophecy.ClaimValidators[claim]
-	prophecy.ClaimValidators[claim] = append(claimValidators, validator)
+// ScopedID returns the id the prophecy is stored under
+func (dbProphecy DBProphecy) ScopedID() string {
+	return ScopedProphecyID(dbProphecy.Namespace, dbProphecy.ID)
+}
 
-	validatorBech32 := validator.String()
-	prophecy.ValidatorClaims[validatorBech32] = claim
+// HasValidatorPowers returns whether the validator set was snapshotted for the prophecy. Prophecies created
+// before validator sets were snapshotted have none.
+func (dbProphecy DBProphecy) HasValidatorPowers() bool {
+	return dbProphecy.TotalPower.IsPositive()
 }
 
-// FindHighestClaim looks through all the existing claims on a given prophecy. It adds up the total power across
-// all claims and returns the highest claim, power for that claim, and total power claimed on the prophecy overall.
-func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKeeper) (string, int64, int64) {
-	validators := stakeKeeper.GetBondedValidatorsByPower(ctx)
-	//Index the validators by address for looking when scanning through claims
-	validatorsByAddress := make(map[string]staking.Validator)
-	for _, validator := range validators {
-		validatorsByAddress[validator.OperatorAddress.String()] = validator
+// GetRejectedPower returns the power of the validators that rejected the prophecy. Prophecies stored before
+// rejections existed have no rejected power stored.
+func (dbProphecy DBProphecy) GetRejectedPower() sdk.Int {
+	if dbProphecy.RejectedPower == (sdk.Int{}) {
+		return sdk.ZeroInt()
+	}
+	return dbProphecy.RejectedPower
+}
+
+// AddClaimPower adds the power of a validator to the tally of the claim it made
+func (dbProphecy *DBProphecy) AddClaimPower(claim string, power int64) {
+	i := sort.Search(len(dbProphecy.ClaimPowers), func(i int) bool {
+		return dbProphecy.ClaimPowers[i].Claim >= claim
+	})
+	if i < len(dbProphecy.ClaimPowers) && dbProphecy.ClaimPowers[i].Claim == claim {
+		dbProphecy.ClaimPowers[i].Power = dbProphecy.ClaimPowers[i].Power.AddRaw(power)
+		return
 	}
 
-	totalClaimsPower := int64(0)
-	highestClaimPower := int64(-1)
+	dbProphecy.ClaimPowers = append(dbProphecy.ClaimPowers, ClaimPower{})
+	copy(dbProphecy.ClaimPowers[i+1:], dbProphecy.ClaimPowers[i:])
+	dbProphecy.ClaimPowers[i] = ClaimPower{Claim: claim, Power: sdk.NewInt(power)}
+}
+
+// FindHighestClaim returns the claim with the highest power behind it, that power and the total power claimed
+// on the prophecy. Of claims with equal power, the one that sorts first is returned.
+func (dbProphecy DBProphecy) FindHighestClaim() (string, sdk.Int, sdk.Int) {
+	totalClaimsPower := sdk.ZeroInt()
+	highestClaimPower := sdk.ZeroInt()
 	highestClaim := ""
-	for claim, validatorAddrs := range prophecy.ClaimValidators {
-		claimPower := int64(0)
-		for _, validatorAddr := range validatorAddrs {
-			validator, found := validatorsByAddress[validatorAddr.String()]
-			if found {
-				// Note: If claim validator is not found in the current validator set, we assume it is no longer
-				// an active validator and so can silently ignore it's claim and no longer count it towards total power.
-				claimPower += validator.GetConsensusPower()
-			}
-		}
-		totalClaimsPower += claimPower
-		if claimPower > highestClaimPower {
-			highestClaimPower = claimPower
-			highestClaim = claim
+	for _, claimPower := range dbProphecy.ClaimPowers {
+		totalClaimsPower = totalClaimsPower.Add(claimPower.Power)
+		if highestClaim == "" || claimPower.Power.GT(highestClaimPower) {
+			highestClaimPower = claimPower.Power
+			highestClaim = claimPower.Claim
 		}
 	}
 	return highestClaim, highestClaimPower, totalClaimsPower
 }
 
-// NewProphecy returns a new Prophecy, initialized in pending status with an initial claim
-func NewProphecy(id string) Prophecy {
+// FindWeightedMedianClaim returns the power-weighted median of the numeric claims of the prophecy and the total
+// power claimed on it. The median is the lowest claim such that the claims up to it hold at least half of the
+// claimed power, so it is always one of the claims made. It returns an error if a claim is not a decimal number.
+func (dbProphecy DBProphecy) FindWeightedMedianClaim() (string, sdk.Int, error) {
+	values := make([]sdk.Dec, len(dbProphecy.ClaimPowers))
+	order := make([]int, len(dbProphecy.ClaimPowers))
+	totalClaimsPower := sdk.ZeroInt()
+	for i, claimPower := range dbProphecy.ClaimPowers {
+		value, err := sdk.NewDecFromStr(claimPower.Claim)
+		if err != nil {
+			return "", sdk.Int{}, err
+		}
+		values[i] = value
+		order[i] = i
+		totalClaimsPower = totalClaimsPower.Add(claimPower.Power)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return values[order[i]].LT(values[order[j]])
+	})
+
+	cumulativePower := sdk.ZeroInt()
+	for _, i := range order {
+		cumulativePower = cumulativePower.Add(dbProphecy.ClaimPowers[i].Power)
+		if cumulativePower.MulRaw(2).GTE(totalClaimsPower) {
+			return dbProphecy.ClaimPowers[i].Claim, totalClaimsPower, nil
+		}
+	}
+	return "", totalClaimsPower, nil
+}
+
+// ValidatorClaim is the claim a single validator made on a prophecy
+type ValidatorClaim struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Content   string         `json:"content"`
+	// Height is the height at which the claim was made, or revealed if it was committed to first
+	Height int64 `json:"height"`
+}
+
+// NewValidatorClaim returns a new ValidatorClaim
+func NewValidatorClaim(validator sdk.ValAddress, content string, height int64) ValidatorClaim {
+	return ValidatorClaim{
+		Validator: validator,
+		Content:   content,
+		Height:    height,
+	}
+}
+
+// ValidatorPower is the power of a single validator in a prophecy's validator set snapshot
+type ValidatorPower struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Power     int64          `json:"power"`
+	// Forfeited is set once the validator left the bonded validator set without having claimed, it can then no
+	// longer claim on the prophecy
+	Forfeited bool `json:"forfeited"`
+}
+
+// NewValidatorPower returns a new ValidatorPower
+func NewValidatorPower(validator sdk.ValAddress, power int64) ValidatorPower {
+	return ValidatorPower{
+		Validator: validator,
+		Power:     power,
+	}
+}
+
+// ScopedID returns the id the prophecy is stored under
+func (prophecy Prophecy) ScopedID() string {
+	return ScopedProphecyID(prophecy.Namespace, prophecy.ID)
+}
+
+// AddClaim adds a given claim made at the given height to this prophecy, replacing any claim the validator already
+// made
+func (prophecy *Prophecy) AddClaim(validator sdk.ValAddress, claim string, height int64) {
+	i := prophecy.searchClaim(validator)
+	if i < len(prophecy.Claims) && prophecy.Claims[i].Validator.Equals(validator) {
+		prophecy.Claims[i] = NewValidatorClaim(validator, claim, height)
+		return
+	}
+
+	prophecy.Claims = append(prophecy.Claims, ValidatorClaim{})
+	copy(prophecy.Claims[i+1:], prophecy.Claims[i:])
+	prophecy.Claims[i] = NewValidatorClaim(validator, claim, height)
+}
+
+// GetClaim returns the claim the given validator made on this prophecy, and whether it made one
+func (prophecy Prophecy) GetClaim(validator sdk.ValAddress) (string, bool) {
+	i := prophecy.searchClaim(validator)
+	if i < len(prophecy.Claims) && prophecy.Claims[i].Validator.Equals(validator) {
+		return prophecy.Claims[i].Content, true
+	}
+	return "", false
+}
+
+// hasClaim returns whether any validator made the given claim on the prophecy
+func (prophecy Prophecy) hasClaim(claim string) bool {
+	for _, validatorClaim := range prophecy.Claims {
+		if validatorClaim.Content == claim {
+			return true
+		}
+	}
+	return false
+}
+
+// AddCommit adds a given claim commitment to this prophecy, replacing any commitment the validator already made
+func (prophecy *Prophecy) AddCommit(validator sdk.ValAddress, commitment []byte) {
+	i := prophecy.searchCommit(validator)
+	if i < len(prophecy.Commits) && prophecy.Commits[i].Validator.Equals(validator) {
+		prophecy.Commits[i].Commitment = commitment
+		return
+	}
+
+	prophecy.Commits = append(prophecy.Commits, ValidatorCommit{})
+	copy(prophecy.Commits[i+1:], prophecy.Commits[i:])
+	prophecy.Commits[i] = NewValidatorCommit(validator, commitment)
+}
+
+// GetCommit returns the claim commitment the given validator made on this prophecy, and whether it made one
+func (prophecy Prophecy) GetCommit(validator sdk.ValAddress) ([]byte, bool) {
+	i := prophecy.searchCommit(validator)
+	if i < len(prophecy.Commits) && prophecy.Commits[i].Validator.Equals(validator) {
+		return prophecy.Commits[i].Commitment, true
+	}
+	return nil, false
+}
+
+// AddRejection adds the rejection of the given validator to this prophecy
+func (prophecy *Prophecy) AddRejection(validator sdk.ValAddress) {
+	i := prophecy.searchRejection(validator)
+	if i < len(prophecy.Rejections) && prophecy.Rejections[i].Equals(validator) {
+		return
+	}
+
+	prophecy.Rejections = append(prophecy.Rejections, nil)
+	copy(prophecy.Rejections[i+1:], prophecy.Rejections[i:])
+	prophecy.Rejections[i] = validator
+}
+
+// HasRejected returns whether the given validator rejected this prophecy
+func (prophecy Prophecy) HasRejected(validator sdk.ValAddress) bool {
+	i := prophecy.searchRejection(validator)
+	return i < len(prophecy.Rejections) && prophecy.Rejections[i].Equals(validator)
+}
+
+// searchRejection returns the index of the rejection of the given validator, or the index it is to be inserted at
+func (prophecy Prophecy) searchRejection(validator sdk.ValAddress) int {
+	return sort.Search(len(prophecy.Rejections), func(i int) bool {
+		return bytes.Compare(prophecy.Rejections[i], validator) >= 0
+	})
+}
+
+// searchCommit returns the index of the commitment of the given validator, or the index it is to be inserted at
+func (prophecy Prophecy) searchCommit(validator sdk.ValAddress) int {
+	return sort.Search(len(prophecy.Commits), func(i int) bool {
+		return bytes.Compare(prophecy.Commits[i].Validator, validator) >= 0
+	})
+}
+
+// searchClaim returns the index of the claim of the given validator, or the index it is to be inserted at
+func (prophecy Prophecy) searchClaim(validator sdk.ValAddress) int {
+	return sort.Search(len(prophecy.Claims), func(i int) bool {
+		return bytes.Compare(prophecy.Claims[i].Validator, validator) >= 0
+	})
+}
+
+// GetValidatorPower returns the power of the given validator in the prophecy's validator set snapshot, and
+// whether the validator is part of it at all
+func (prophecy Prophecy) GetValidatorPower(validator sdk.ValAddress) (int64, bool) {
+	i := sort.Search(len(prophecy.ValidatorPowers), func(i int) bool {
+		return bytes.Compare(prophecy.ValidatorPowers[i].Validator, validator) >= 0
+	})
+	if i < len(prophecy.ValidatorPowers) && prophecy.ValidatorPowers[i].Validator.Equals(validator) {
+		return prophecy.ValidatorPowers[i].Power, true
+	}
+	return 0, false
+}
+
+// TotalPower returns the total power of the prophecy's validator set snapshot
+func (prophecy Prophecy) TotalPower() sdk.Int {
+	totalPower := sdk.ZeroInt()
+	for _, validatorPower := range prophecy.ValidatorPowers {
+		totalPower = totalPower.AddRaw(validatorPower.Power)
+	}
+	return totalPower
+}
+
+// FindHighestClaim looks through all the existing claims on a given prophecy. It adds up the total power across
+// all claims and returns the highest claim, power for that claim, and total power claimed on the prophecy overall.
+// Claims are weighted by the validator powers snapshotted when the prophecy was created, so later changes to the
+// validator set do not affect them.
+func (prophecy Prophecy) FindHighestClaim() (string, sdk.Int, sdk.Int) {
+	return prophecy.ToDBProphecy().FindHighestClaim()
+}
+
+// NewProphecy returns a new Prophecy of the namespace, initialized in pending status without any claims
+func NewProphecy(namespace, id string) Prophecy {
 	return Prophecy{
-		ID:              id,
-		Status:          NewStatus(PendingStatusText, ""),
-		ClaimValidators: make(map[string][]sdk.ValAddress),
-		ValidatorClaims: make(map[string]string),
+		Namespace: namespace,
+		ID:        id,
+		Status:    NewStatus(PendingStatusText, ""),
 	}
 }
 
@@ -139,6 +376,9 @@ func NewProphecy(id string) Prophecy {
 type Status struct {
 	Text       StatusText `json:"text"`
 	FinalClaim string     `json:"final_claim"`
+	// Resolved is true when governance resolved the prophecy, whose final claim then need not have been claimed by
+	// any validator
+	Resolved bool `json:"resolved"`
 }
 
 // NewStatus returns a new Status with the given data contained
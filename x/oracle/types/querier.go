@@ -0,0 +1,89 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the oracle Querier
+const (
+	QueryParams       = "params"
+	QueryProphecy     = "prophecy"
+	QueryProphecies   = "prophecies"
+	QueryMisbehaviors = "misbehaviors"
+	QueryLiveness     = "liveness"
+)
+
+// QueryProphecyParams defines the params for the following queries:
+// - 'custom/oracle/prophecy'
+type QueryProphecyParams struct {
+	Namespace string `json:"namespace"`
+	ID        string `json:"id"`
+}
+
+// NewQueryProphecyParams creates a new QueryProphecyParams
+func NewQueryProphecyParams(namespace, id string) QueryProphecyParams {
+	return QueryProphecyParams{
+		Namespace: namespace,
+		ID:        id,
+	}
+}
+
+// QueryPropheciesParams defines the params for the following queries:
+// - 'custom/oracle/prophecies'
+type QueryPropheciesParams struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Status restricts the results to prophecies with the given status, all prophecies are listed when empty
+	Status string `json:"status"`
+	// Namespace restricts the results to prophecies of the given namespace, prophecies of all namespaces are
+	// listed when empty
+	Namespace string `json:"namespace"`
+}
+
+// NewQueryPropheciesParams creates a new QueryPropheciesParams
+func NewQueryPropheciesParams(page, limit int, status, namespace string) QueryPropheciesParams {
+	return QueryPropheciesParams{
+		Page:      page,
+		Limit:     limit,
+		Status:    status,
+		Namespace: namespace,
+	}
+}
+
+// QueryMisbehaviorsParams defines the params for the following queries:
+// - 'custom/oracle/misbehaviors'
+type QueryMisbehaviorsParams struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Validator restricts the results to the misbehaviors of the given validator, all misbehaviors are listed when
+	// empty
+	Validator sdk.ValAddress `json:"validator"`
+}
+
+// NewQueryMisbehaviorsParams creates a new QueryMisbehaviorsParams
+func NewQueryMisbehaviorsParams(page, limit int, validator sdk.ValAddress) QueryMisbehaviorsParams {
+	return QueryMisbehaviorsParams{
+		Page:      page,
+		Limit:     limit,
+		Validator: validator,
+	}
+}
+
+// QueryLivenessParams defines the params for the following queries:
+// - 'custom/oracle/liveness'
+type QueryLivenessParams struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Validator restricts the results to the liveness record of the given validator, all records are listed when
+	// empty
+	Validator sdk.ValAddress `json:"validator"`
+}
+
+// NewQueryLivenessParams creates a new QueryLivenessParams
+func NewQueryLivenessParams(page, limit int, validator sdk.ValAddress) QueryLivenessParams {
+	return QueryLivenessParams{
+		Page:      page,
+		Limit:     limit,
+		Validator: validator,
+	}
+}
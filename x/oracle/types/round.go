@@ -0,0 +1,36 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProphecyRound is a past voting round of a prophecy. A prophecy that failed or expired can be reopened for a new
+// round, its claims and rejections of the previous rounds are kept as ProphecyRounds for audit.
+type ProphecyRound struct {
+	Round           uint64           `json:"round"`
+	Status          Status           `json:"status"`
+	CreationHeight  int64            `json:"creation_height"`
+	FinalizedHeight int64            `json:"finalized_height"`
+	ValidatorPowers []ValidatorPower `json:"validator_powers"`
+	Claims          []ValidatorClaim `json:"claims"`
+	Rejections      []sdk.ValAddress `json:"rejections"`
+}
+
+// NewProphecyRound returns the current round of the given prophecy as a past round
+func NewProphecyRound(prophecy Prophecy) ProphecyRound {
+	return ProphecyRound{
+		Round:           prophecy.Round,
+		Status:          prophecy.Status,
+		CreationHeight:  prophecy.CreationHeight,
+		FinalizedHeight: prophecy.FinalizedHeight,
+		ValidatorPowers: prophecy.ValidatorPowers,
+		Claims:          prophecy.Claims,
+		Rejections:      prophecy.Rejections,
+	}
+}
+
+// CanReopen returns whether a prophecy with the given status can be reopened for a new round. Successful prophecies
+// are never reopened, so that their claim cannot be processed twice.
+func CanReopen(status StatusText) bool {
+	return status == FailedStatusText || status == ExpiredStatusText
+}
@@ -13,13 +13,15 @@ const (
 	PendingStatusText StatusText = iota
 	SuccessStatusText
 	FailedStatusText
+	ExpiredStatusText
 )
 
-var StatusTextToString = [...]string{"pending", "success", "failed"}
+var StatusTextToString = [...]string{"pending", "success", "failed", "expired"}
 var StringToStatusText = map[string]StatusText{
 	"pending": PendingStatusText,
 	"success": SuccessStatusText,
 	"failed":  FailedStatusText,
+	"expired": ExpiredStatusText,
 }
 
 func (text StatusText) String() string {